@@ -0,0 +1,314 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package artifact splits artifact file names, such as
+// "agent-1.4.2-linux-amd64.tar.gz" or "tool_v2.0_darwin.zip", into their
+// name, semver.Number, operating system, architecture and extension
+// following templates, formats them back, and picks the newest artifact
+// built for a platform.
+//
+// Templates are made of literal text and the placeholders {name},
+// {version}, {os}, {arch} and {ext}, e.g. "{name}-{version}-{os}-{arch}{ext}".
+// Only {version} is required and each placeholder may appear once.
+package artifact
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+var (
+	// ErrInvalidTemplate is the error produced for malformed templates.
+	ErrInvalidTemplate = errors.New("artifact: invalid template")
+	// ErrNoMatch is the error produced for file names
+	// which don't follow the template.
+	ErrNoMatch = errors.New("artifact: file name doesn't match the template")
+)
+
+// Artifact is the description of an artifact file.
+type Artifact struct {
+	Filename string
+	Name     string
+	Number   semver.Number
+	OS, Arch string
+	// Ext is the extension, dot included, e.g. ".tar.gz".
+	Ext string
+}
+
+type field byte
+
+const (
+	literal field = iota
+	fieldName
+	fieldVersion
+	fieldOS
+	fieldArch
+	fieldExt
+)
+
+var fields = map[string]field{
+	"name":    fieldName,
+	"version": fieldVersion,
+	"os":      fieldOS,
+	"arch":    fieldArch,
+	"ext":     fieldExt,
+}
+
+type part struct {
+	field field
+	text  string
+}
+
+// Template describes the file names of artifacts.
+type Template struct {
+	s     string
+	parts []part
+}
+
+// NewTemplate parses the template s.
+func NewTemplate(s string) (*Template, error) {
+	t := &Template{s: s}
+	seen := map[field]bool{}
+	for rest := s; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			open = len(rest)
+		}
+		if strings.IndexByte(rest[:open], '}') >= 0 {
+			return nil, fmt.Errorf("%w %q: unexpected '}'", ErrInvalidTemplate, s)
+		}
+		if open > 0 {
+			t.parts = append(t.parts, part{literal, rest[:open]})
+		}
+		if rest = rest[open:]; rest == "" {
+			break
+		}
+
+		end := strings.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w %q: unterminated placeholder", ErrInvalidTemplate, s)
+		}
+		f, ok := fields[rest[1:end]]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w %q: unknown placeholder %s", ErrInvalidTemplate, s, rest[:end+1])
+		case seen[f]:
+			return nil, fmt.Errorf("%w %q: repeated placeholder %s", ErrInvalidTemplate, s, rest[:end+1])
+		}
+		seen[f] = true
+		t.parts = append(t.parts, part{f, ""})
+		rest = rest[end+1:]
+	}
+	if !seen[fieldVersion] {
+		return nil, fmt.Errorf("%w %q: missing {version}", ErrInvalidTemplate, s)
+	}
+	return t, nil
+}
+
+// MustTemplate is like NewTemplate but panics if the template is malformed.
+func MustTemplate(s string) *Template {
+	t, err := NewTemplate(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String satisfies the fmt.Stringer interface.
+func (t *Template) String() string { return t.s }
+
+// Format returns the file name of the Artifact,
+// its Number formatted as major.minor.patch.
+func (t *Template) Format(a Artifact) string {
+	var b strings.Builder
+	for _, p := range t.parts {
+		switch p.field {
+		case literal:
+			b.WriteString(p.text)
+		case fieldName:
+			b.WriteString(a.Name)
+		case fieldVersion:
+			b.WriteString(a.Number.GoString())
+		case fieldOS:
+			b.WriteString(a.OS)
+		case fieldArch:
+			b.WriteString(a.Arch)
+		case fieldExt:
+			b.WriteString(a.Ext)
+		}
+	}
+	return b.String()
+}
+
+// Parse splits the file name following the Template.
+func (t *Template) Parse(filename string) (Artifact, error) {
+	a := Artifact{Filename: filename}
+	if !t.match(t.parts, filename, &a) {
+		return Artifact{}, fmt.Errorf("%w: %q does not follow %q", ErrNoMatch, filename, t.s)
+	}
+	return a, nil
+}
+
+// match matches s against the parts, backtracking through the possible
+// lengths of each placeholder: the longest first for {version} and {ext},
+// the shortest first for the others.
+func (t *Template) match(parts []part, s string, a *Artifact) bool {
+	if len(parts) == 0 {
+		return s == ""
+	}
+	p := parts[0]
+	if p.field == literal {
+		return strings.HasPrefix(s, p.text) && t.match(parts[1:], s[len(p.text):], a)
+	}
+
+	try := func(n int) bool {
+		v := s[:n]
+		switch p.field {
+		case fieldName:
+			a.Name = v
+		case fieldVersion:
+			// unlike ParseNumber, reject empty and extra components,
+			// e.g. "1..2" or "1.2.3.4", rather than read another Number.
+			// Format still writes all three, so "2.0" comes back as "2.0.0".
+			components := strings.Split(v, ".")
+			if len(components) > 3 {
+				return false
+			}
+			for _, c := range components {
+				if c == "" {
+					return false
+				}
+			}
+			number, err := semver.ParseNumber(v)
+			if err != nil {
+				return false
+			}
+			a.Number = number
+		case fieldOS:
+			a.OS = v
+		case fieldArch:
+			a.Arch = v
+		case fieldExt:
+			a.Ext = v
+		}
+		return t.match(parts[1:], s[n:], a)
+	}
+
+	limit := 0
+	for limit < len(s) && valid(p.field, s[limit]) {
+		limit++
+	}
+	if p.field == fieldName {
+		limit = len(s)
+	}
+	switch p.field {
+	case fieldVersion:
+		for n := limit; n > 0; n-- {
+			if try(n) {
+				return true
+			}
+		}
+		return false
+	case fieldExt:
+		for n := limit; n >= 0; n-- {
+			if isExt(s[:n]) && try(n) {
+				return true
+			}
+		}
+		return false
+	}
+	for n := 1; n <= limit; n++ {
+		if try(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// valid tells whether c may be part of the value of the field.
+func valid(f field, c byte) bool {
+	alnum := c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	switch f {
+	case fieldVersion:
+		return c >= '0' && c <= '9' || c == '.'
+	case fieldOS, fieldArch:
+		return alnum || c == '_'
+	case fieldExt:
+		return alnum || c == '.'
+	}
+	return true
+}
+
+// isExt tells whether s is an extension: empty or dot
+// separated segments holding a letter each, e.g. ".tar.gz" or ".7z".
+func isExt(s string) bool {
+	if s == "" {
+		return true
+	}
+	if s[0] != '.' {
+		return false
+	}
+	for _, seg := range strings.Split(s[1:], ".") {
+		if strings.IndexFunc(seg, func(r rune) bool { return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' }) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Templates is a list of Template values tried in order.
+type Templates []*Template
+
+// Parse splits the file name following the first Template it matches.
+func (ts Templates) Parse(filename string) (Artifact, error) {
+	for _, t := range ts {
+		if a, err := t.Parse(filename); err == nil {
+			return a, nil
+		}
+	}
+	return Artifact{}, fmt.Errorf("%w: %q", ErrNoMatch, filename)
+}
+
+// Newest returns the newest artifact among the file names built for the
+// operating system and architecture. Artifacts whose template lacks {os}
+// or {arch} are taken as built for any of them, and file names matching
+// none of the Templates are ignored.
+func (ts Templates) Newest(filenames []string, os, arch string) (Artifact, bool) {
+	var as []Artifact
+	for _, filename := range filenames {
+		if a, err := ts.Parse(filename); err == nil {
+			as = append(as, a)
+		}
+	}
+	return Newest(as, os, arch)
+}
+
+// Newest returns the Artifact with the greatest Number among those built
+// for the operating system and architecture. An Artifact with no OS or Arch
+// is taken as built for any of them. The first one listed wins a tie.
+func Newest(as []Artifact, os, arch string) (Artifact, bool) {
+	var (
+		ns    semver.Numbers
+		byNum = map[semver.Number]Artifact{}
+	)
+	for _, a := range as {
+		if a.OS != "" && a.OS != os || a.Arch != "" && a.Arch != arch {
+			continue
+		}
+		if _, ok := byNum[a.Number]; !ok {
+			byNum[a.Number] = a
+			ns = append(ns, a.Number)
+		}
+	}
+	if len(ns) == 0 {
+		return Artifact{}, false
+	}
+	sort.Sort(ns)
+	return byNum[ns[len(ns)-1]], true
+}
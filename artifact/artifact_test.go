@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package artifact_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/artifact"
+)
+
+func TestNewTemplate(t *testing.T) {
+	var tcs = []struct {
+		s   string
+		err bool
+	}{
+		{"{name}-{version}-{os}-{arch}{ext}", false},
+		{"tool_v{version}_{os}.zip", false},
+		{"{version}", false},
+		{"{name}-{os}", true},
+		{"{name}-{version}-{name}", true},
+		{"{name}-{version}-{platform}", true},
+		{"{name}-{version", true},
+		{"name}-{version}", true},
+	}
+
+	for i, tc := range tcs {
+		_, err := artifact.NewTemplate(tc.s)
+		if (err != nil) != tc.err || err != nil && !errors.Is(err, artifact.ErrInvalidTemplate) {
+			t.Errorf("tc[%d] %s error mismatch expected: %t got: %v", i, tc.s, tc.err, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	var (
+		dashed  = artifact.MustTemplate("{name}-{version}-{os}-{arch}{ext}")
+		snake   = artifact.MustTemplate("{name}_v{version}_{os}{ext}")
+		archFst = artifact.MustTemplate("{name}_{version}_{os}_{arch}{ext}")
+		bare    = artifact.MustTemplate("{name}-{version}{ext}")
+	)
+
+	var tcs = []struct {
+		t        *artifact.Template
+		filename string
+		exp      artifact.Artifact
+		err      bool
+	}{
+		{dashed, "agent-1.4.2-linux-amd64.tar.gz", artifact.Artifact{Name: "agent", Number: semver.NewNumber(1, 4, 2), OS: "linux", Arch: "amd64", Ext: ".tar.gz"}, false},
+		{dashed, "my-agent-1.4-darwin-arm64", artifact.Artifact{Name: "my-agent", Number: semver.NewNumber(1, 4, 0), OS: "darwin", Arch: "arm64"}, false},
+		{dashed, "agent2-10.0.1-windows-386.exe", artifact.Artifact{Name: "agent2", Number: semver.NewNumber(10, 0, 1), OS: "windows", Arch: "386", Ext: ".exe"}, false},
+		{snake, "tool_v2.0_darwin.zip", artifact.Artifact{Name: "tool", Number: semver.NewNumber(2, 0, 0), OS: "darwin", Ext: ".zip"}, false},
+		{archFst, "db_tool_1.2.3_linux_x86_64.tar.xz", artifact.Artifact{Name: "db_tool", Number: semver.NewNumber(1, 2, 3), OS: "linux", Arch: "x86_64", Ext: ".tar.xz"}, false},
+		{bare, "tool-1.2.3.7z", artifact.Artifact{Name: "tool", Number: semver.NewNumber(1, 2, 3), Ext: ".7z"}, false},
+		{dashed, "agent-1.256-linux-amd64", artifact.Artifact{}, true},
+		{dashed, "agent-1.4.2.3-linux-amd64.tar.gz", artifact.Artifact{}, true},
+		{dashed, "agent-1..2-linux-amd64.tar.gz", artifact.Artifact{}, true},
+		{dashed, "agent-1.4.-linux-amd64.tar.gz", artifact.Artifact{}, true},
+		{bare, "tool-.1.2.zip", artifact.Artifact{}, true},
+		{dashed, "agent-latest-linux-amd64.tar.gz", artifact.Artifact{}, true},
+		{snake, "tool_2.0_darwin.zip", artifact.Artifact{}, true},
+	}
+
+	for i, tc := range tcs {
+		a, err := tc.t.Parse(tc.filename)
+		if (err != nil) != tc.err || err != nil && !errors.Is(err, artifact.ErrNoMatch) {
+			t.Errorf("tc[%d] %s error mismatch expected: %t got: %v", i, tc.filename, tc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		tc.exp.Filename = tc.filename
+		if a != tc.exp {
+			t.Errorf("tc[%d] mismatch expected: %+v got: %+v", i, tc.exp, a)
+		}
+	}
+}
+
+func TestNewest(t *testing.T) {
+	ts := artifact.Templates{
+		artifact.MustTemplate("{name}-{version}-{os}-{arch}{ext}"),
+		artifact.MustTemplate("{name}_v{version}_{os}{ext}"),
+	}
+	filenames := []string{
+		"agent-1.4.2-linux-amd64.tar.gz",
+		"agent-1.10.0-linux-arm64.tar.gz",
+		"agent-1.9.0-linux-amd64.tar.gz",
+		"agent-1.9.0-linux-amd64.zip",
+		"agent_v1.9.5_linux.tar.gz",
+		"agent_v2.0_darwin.zip",
+		"README.md",
+	}
+
+	var tcs = []struct {
+		os, arch string
+		exp      string
+	}{
+		{"linux", "amd64", "agent_v1.9.5_linux.tar.gz"},
+		{"linux", "arm64", "agent-1.10.0-linux-arm64.tar.gz"},
+		{"darwin", "arm64", "agent_v2.0_darwin.zip"},
+		{"windows", "amd64", ""},
+	}
+
+	for i, tc := range tcs {
+		a, ok := ts.Newest(filenames, tc.os, tc.arch)
+		if ok != (tc.exp != "") || a.Filename != tc.exp {
+			t.Errorf("tc[%d] %s/%s mismatch expected: %q got: %q", i, tc.os, tc.arch, tc.exp, a.Filename)
+		}
+	}
+
+	if a, _ := artifact.Newest([]artifact.Artifact{
+		{Filename: "a.tar.gz", Number: semver.NewNumber(1, 0, 0)},
+		{Filename: "a.zip", Number: semver.NewNumber(1, 0, 0)},
+	}, "linux", "amd64"); a.Filename != "a.tar.gz" {
+		t.Errorf("tie mismatch expected: %s got: %s", "a.tar.gz", a.Filename)
+	}
+}
+
+func ExampleTemplate_Format() {
+	t := artifact.MustTemplate("{name}-{version}-{os}-{arch}{ext}")
+	a, _ := t.Parse("agent-1.4-linux-amd64.tar.gz")
+	a.Number, _ = a.Number.Bump(semver.PartPatch)
+	fmt.Println(t.Format(a))
+	// Output: agent-1.4.1-linux-amd64.tar.gz
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:build go1.18
+// +build go1.18
+
+// Package buildinfo reads the version embedded in Go binaries as a
+// semver.Number, either from the running program or from a binary on disk.
+//
+// The version is taken from the first of:
+//
+//	a variable named Version or version set with -ldflags "-X pkg.Version=1.2.3"
+//	the version of the main module, as set by go install pkg@v1.2.3
+//
+// The package requires Go 1.18 or later.
+package buildinfo
+
+import (
+	gobuildinfo "debug/buildinfo"
+	"errors"
+	"runtime/debug"
+	"strings"
+
+	"github.com/vilarfg/go-semver32/gomod"
+)
+
+var (
+	// ErrNoBuildInfo is the error produced when the running program
+	// wasn't built with module support.
+	ErrNoBuildInfo = errors.New("buildinfo: no build information")
+	// ErrNoVersion is the error produced when the build information
+	// holds no version, as it happens with go run and go build.
+	ErrNoVersion = errors.New("buildinfo: no version")
+)
+
+// Source is where a version was found within the build information.
+type Source byte
+
+const (
+	// SourceLdflags is a variable set with the -X linker flag.
+	SourceLdflags Source = iota + 1
+	// SourceModule is the version of the main module.
+	SourceModule
+)
+
+// String satisfies the fmt.Stringer interface.
+func (s Source) String() string {
+	switch s {
+	case SourceLdflags:
+		return "ldflags"
+	case SourceModule:
+		return "module"
+	}
+	return "unknown"
+}
+
+// Info is the version of a Go binary.
+type Info struct {
+	// Version holds the Number and whatever its conversion dropped,
+	// see gomod.Version.Lossy.
+	gomod.Version
+	// Raw is the version as it was found.
+	Raw    string
+	Source Source
+	// Revision and Modified describe the state of the version control
+	// checkout the binary was built from, when known.
+	Revision string
+	Modified bool
+}
+
+// FromBuildInfo returns the version of the running program.
+func FromBuildInfo() (Info, error) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Info{}, ErrNoBuildInfo
+	}
+	return Parse(bi)
+}
+
+// FromBinary returns the version of the Go binary at path.
+func FromBinary(path string) (Info, error) {
+	bi, err := gobuildinfo.ReadFile(path)
+	if err != nil {
+		return Info{}, err
+	}
+	return Parse(bi)
+}
+
+// Parse returns the version recorded in the build information.
+func Parse(bi *debug.BuildInfo) (Info, error) {
+	var info Info
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "-ldflags":
+			if raw, ok := ldflagsVersion(s.Value); ok && info.Raw == "" {
+				info.Raw, info.Source = raw, SourceLdflags
+			}
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	if info.Raw == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Raw, info.Source = bi.Main.Version, SourceModule
+	}
+	if info.Raw == "" {
+		return Info{}, ErrNoVersion
+	}
+
+	parse := gomod.Parse
+	if info.Source == SourceLdflags {
+		parse = gomod.ParseLenient
+	}
+	v, err := parse(info.Raw)
+	if err != nil {
+		return Info{}, err
+	}
+	info.Version = v
+	return info, nil
+}
+
+// ldflagsVersion returns the value of the first Version or version
+// variable set with -X in the linker flags.
+func ldflagsVersion(ldflags string) (string, bool) {
+	args := fields(ldflags)
+	for i, arg := range args {
+		var def string
+		switch {
+		case (arg == "-X" || arg == "--X") && i+1 < len(args):
+			def = args[i+1]
+		case strings.HasPrefix(arg, "-X="):
+			def = arg[len("-X="):]
+		case strings.HasPrefix(arg, "--X="):
+			def = arg[len("--X="):]
+		default:
+			continue
+		}
+
+		eq := strings.IndexByte(def, '=')
+		if eq < 0 {
+			continue
+		}
+		name := def[:eq]
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+			name = name[dot+1:]
+		}
+		if name == "Version" || name == "version" {
+			return def[eq+1:], true
+		}
+	}
+	return "", false
+}
+
+// fields splits s around spaces, honoring single and double quotes.
+func fields(s string) []string {
+	var (
+		fs    []string
+		b     strings.Builder
+		quote rune
+		in    bool
+	)
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			b.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote, in = r, true
+		case r == ' ' || r == '\t':
+			if in {
+				fs = append(fs, b.String())
+				b.Reset()
+				in = false
+			}
+		default:
+			b.WriteRune(r)
+			in = true
+		}
+	}
+	if in {
+		fs = append(fs, b.String())
+	}
+	return fs
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+//go:build go1.18
+// +build go1.18
+
+package buildinfo_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/buildinfo"
+)
+
+func TestParse(t *testing.T) {
+	var tcs = []struct {
+		main     string
+		settings map[string]string
+		number   semver.Number
+		raw      string
+		source   buildinfo.Source
+		lossy    bool
+		err      error
+	}{
+		{"v1.2.3", nil, semver.NewNumber(1, 2, 3), "v1.2.3", buildinfo.SourceModule, false, nil},
+		{"v0.0.0-20200102150405-abcdef123456", nil, semver.NewNumber(0, 0, 0), "v0.0.0-20200102150405-abcdef123456", buildinfo.SourceModule, true, nil},
+		{"(devel)", map[string]string{"-ldflags": "-s -w -X main.version=1.4.0"}, semver.NewNumber(1, 4, 0), "1.4.0", buildinfo.SourceLdflags, false, nil},
+		{"(devel)", map[string]string{"-ldflags": "-X main.version=1.2"}, semver.NewNumber(1, 2, 0), "1.2", buildinfo.SourceLdflags, false, nil},
+		{"v1.2.3", map[string]string{"-ldflags": "-X 'example.com/app/internal.Version=v2.0.0-rc.1' -X main.Version=9.9.9"}, semver.NewNumber(2, 0, 0), "v2.0.0-rc.1", buildinfo.SourceLdflags, true, nil},
+		{"v1.2.3", map[string]string{"-ldflags": "-X=main.commit=abc -X main.name=app"}, semver.NewNumber(1, 2, 3), "v1.2.3", buildinfo.SourceModule, false, nil},
+		{"(devel)", map[string]string{"-ldflags": "-s -w"}, 0, "", 0, false, buildinfo.ErrNoVersion},
+		{"", nil, 0, "", 0, false, buildinfo.ErrNoVersion},
+	}
+
+	for i, tc := range tcs {
+		bi := &debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: tc.main}}
+		for k, v := range tc.settings {
+			bi.Settings = append(bi.Settings, debug.BuildSetting{Key: k, Value: v})
+		}
+
+		info, err := buildinfo.Parse(bi)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("tc[%d] error mismatch expected: %v got: %v", i, tc.err, err)
+			continue
+		}
+		if info.Number != tc.number || info.Raw != tc.raw || info.Source != tc.source || info.Lossy() != tc.lossy {
+			t.Errorf("tc[%d] mismatch expected: %s %q %s %t got: %s %q %s %t", i,
+				tc.number.GoString(), tc.raw, tc.source, tc.lossy,
+				info.Number.GoString(), info.Raw, info.Source, info.Lossy())
+		}
+	}
+}
+
+func TestFromBinary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+
+	dir, err := ioutil.TempDir("", "buildinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.18\n",
+		"main.go": "package main\n\nvar version string\n\nfunc main() { println(version) }\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(gobin, "build", "-buildvcs=false", "-o", "app", "-ldflags", "-X main.version=1.5.2")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	info, err := buildinfo.FromBinary(filepath.Join(dir, "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Number != semver.NewNumber(1, 5, 2) || info.Source != buildinfo.SourceLdflags {
+		t.Errorf("mismatch expected: 1.5.2 ldflags got: %s %s", info.Number.GoString(), info.Source)
+	}
+
+	if _, err := buildinfo.FromBinary(filepath.Join(dir, "main.go")); err == nil {
+		t.Error("error expected for a file which isn't a Go binary")
+	}
+}
+
+func TestFromBuildInfo(t *testing.T) {
+	// test binaries carry no version.
+	if _, err := buildinfo.FromBuildInfo(); err != nil && !errors.Is(err, buildinfo.ErrNoVersion) {
+		t.Errorf("error mismatch expected: %v got: %v", buildinfo.ErrNoVersion, err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import "net/textproto"
+
+// Carrier is a key/value store Numbers can be propagated through,
+// such as HTTP headers, URL query values or gRPC metadata.
+type Carrier interface {
+	// Get returns the value associated with the key, or "" if none.
+	Get(key string) string
+	// Set associates the value with the key, replacing any existing ones.
+	Set(key, value string)
+}
+
+// Inject stores n under the key in the Carrier.
+func Inject(c Carrier, key string, n Number) { c.Set(key, n.String()) }
+
+// Extract parses the Number stored under the key in the Carrier.
+// It produces an ErrorEmpty error if there is none.
+func Extract(c Carrier, key string) (Number, error) { return ParseNumber(c.Get(key)) }
+
+// HeaderCarrier adapts a net/http.Header, or any other MIME header,
+// to the Carrier interface: HeaderCarrier(r.Header).
+// Keys are canonicalized like net/http.Header does.
+type HeaderCarrier map[string][]string
+
+// Get satisfies the Carrier interface.
+func (h HeaderCarrier) Get(key string) string {
+	return textproto.MIMEHeader(h).Get(key)
+}
+
+// Set satisfies the Carrier interface.
+func (h HeaderCarrier) Set(key, value string) {
+	textproto.MIMEHeader(h).Set(key, value)
+}
+
+// ValuesCarrier adapts a net/url.Values, a gRPC metadata.MD or any other
+// multi-valued map to the Carrier interface: ValuesCarrier(r.URL.Query()).
+// Keys are used as they are, so gRPC metadata keys must be lowercase.
+type ValuesCarrier map[string][]string
+
+// Get satisfies the Carrier interface.
+func (v ValuesCarrier) Get(key string) string {
+	if vs := v[key]; len(vs) > 0 {
+		return vs[0]
+	}
+	return ""
+}
+
+// Set satisfies the Carrier interface.
+func (v ValuesCarrier) Set(key, value string) { v[key] = []string{value} }
+
+// MapCarrier adapts a map[string]string to the Carrier interface.
+type MapCarrier map[string]string
+
+// Get satisfies the Carrier interface.
+func (m MapCarrier) Get(key string) string { return m[key] }
+
+// Set satisfies the Carrier interface.
+func (m MapCarrier) Set(key, value string) { m[key] = value }
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestCarriers(t *testing.T) {
+	header := http.Header{}
+	values := url.Values{}
+	m := map[string]string{}
+
+	var tcs = []struct {
+		c      semver.Carrier
+		key    string
+		stored func() string
+	}{
+		{semver.HeaderCarrier(header), "x-client-version", func() string { return header.Get("X-Client-Version") }},
+		{semver.ValuesCarrier(values), "client-version", func() string { return values.Get("client-version") }},
+		{semver.MapCarrier(m), "client-version", func() string { return m["client-version"] }},
+	}
+
+	n := semver.NewNumber(3, 2, 1)
+	for i, tc := range tcs {
+		var ee semver.ErrorEmpty
+		if _, err := semver.Extract(tc.c, tc.key); !errors.As(err, &ee) {
+			t.Errorf("tc[%d] empty error expected got: %v", i, err)
+		}
+
+		semver.Inject(tc.c, tc.key, semver.NewNumber(1, 0, 0))
+		semver.Inject(tc.c, tc.key, n)
+		if got := tc.stored(); got != "3.2.1" {
+			t.Errorf("tc[%d] stored value mismatch expected: 3.2.1 got: %s", i, got)
+		}
+		if got, err := semver.Extract(tc.c, tc.key); err != nil {
+			t.Errorf("tc[%d] no extraction error expected got: %s", i, err.Error())
+		} else if got != n {
+			t.Errorf("tc[%d] extracted mismatch expected: %s got: %s", i, n, got)
+		}
+
+		tc.c.Set(tc.key, "3.x")
+		var ice semver.ErrorInvalidCharacter
+		if _, err := semver.Extract(tc.c, tc.key); !errors.As(err, &ice) {
+			t.Errorf("tc[%d] invalid character error expected got: %v", i, err)
+		}
+	}
+}
+
+func ExampleExtract() {
+	min := semver.NewNumber(3, 2, 0)
+
+	// written once, reusable for any transport.
+	enforce := func(ctx context.Context, c semver.Carrier) (context.Context, error) {
+		n, err := semver.Extract(c, "client-version")
+		if err != nil {
+			return ctx, err
+		}
+		if n < min {
+			return ctx, fmt.Errorf("client %s is older than %s", n, min)
+		}
+		return semver.WithNumber(ctx, n), nil
+	}
+
+	h := http.Header{}
+	h.Set("Client-Version", "3.1")
+	_, err := enforce(context.Background(), semver.HeaderCarrier(h))
+	fmt.Println(err)
+
+	ctx, _ := enforce(context.Background(), semver.MapCarrier{"client-version": "3.4.1"})
+	fmt.Println(semver.FromContext(ctx))
+	// Output:
+	// client 3.1 is older than 3.2
+	// 3.4.1 true
+}
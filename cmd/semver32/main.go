@@ -0,0 +1,355 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Command semver32 parses, bumps, compares, sorts and formats
+// 32 bit SemVer numbers, so that shell scripts and Makefiles don't have to.
+//
+// Usage:
+//
+//	semver32 parse <version>
+//	semver32 bump major|minor|patch <version>
+//	semver32 compare <a> <b>
+//	semver32 sort [-r] < versions
+//	semver32 max [versions...]
+//	semver32 satisfies <constraint> [versions...]
+//	semver32 format [--canonical|--short|--int] <version>
+//	semver32 file [-n] major|minor|patch <file>...
+//
+// The file command bumps the version stored in VERSION, Go source
+// (const Version = "1.2.3"), package.json and Chart.yaml files in place.
+// With -n it only prints the diff of the changes it would make.
+//
+// Commands reading versions fall back to reading them from the standard
+// input, one per line, when none are given as arguments.
+//
+// Exit codes:
+//
+//	0   success; for compare, a equals b
+//	1   for satisfies, some version doesn't satisfy the constraint
+//	2   invalid usage
+//	3   empty version
+//	4   invalid character
+//	5   major component too big
+//	6   minor component too big
+//	7   patch component too big
+//	8   any other error
+//	11  for compare, a is lower than b
+//	12  for compare, a is greater than b
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/versionfile"
+)
+
+const (
+	exitOK = iota
+	exitFalse
+	exitUsage
+	exitEmpty
+	exitInvalidCharacter
+	exitMajorTooBig
+	exitMinorTooBig
+	exitPatchTooBig
+	exitError
+)
+
+const (
+	exitLower   = 11
+	exitGreater = 12
+)
+
+var errUsage = errors.New("usage: semver32 parse|bump|compare|sort|max|satisfies|format|file [arguments]")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command described by args and returns its exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		return fail(stderr, errUsage)
+	}
+
+	var cmd func([]string, io.Reader, io.Writer) (int, error)
+	switch args[0] {
+	case "parse":
+		cmd = parse
+	case "bump":
+		cmd = bump
+	case "compare":
+		cmd = compare
+	case "sort":
+		cmd = sortCmd
+	case "max":
+		cmd = maxCmd
+	case "satisfies":
+		cmd = satisfies
+	case "format":
+		cmd = format
+	case "file":
+		cmd = file
+	default:
+		return fail(stderr, errUsage)
+	}
+
+	code, err := cmd(args[1:], stdin, stdout)
+	if err != nil {
+		return fail(stderr, err)
+	}
+	return code
+}
+
+// fail reports err and returns the exit code for its kind.
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, err.Error())
+
+	var (
+		ice  semver.ErrorInvalidCharacter
+		Mtb  semver.ErrorMajorTooBig
+		mtb  semver.ErrorMinorTooBig
+		ptb  semver.ErrorPatchTooBig
+		empt semver.ErrorEmpty
+	)
+	switch {
+	case errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp):
+		return exitUsage
+	case errors.As(err, &empt):
+		return exitEmpty
+	case errors.As(err, &ice):
+		return exitInvalidCharacter
+	case errors.As(err, &Mtb):
+		return exitMajorTooBig
+	case errors.As(err, &mtb):
+		return exitMinorTooBig
+	case errors.As(err, &ptb):
+		return exitPatchTooBig
+	}
+	return exitError
+}
+
+func usage(format string) error { return fmt.Errorf("%w\n\tsemver32 %s", errUsage, format) }
+
+func parse(args []string, _ io.Reader, stdout io.Writer) (int, error) {
+	if len(args) != 1 {
+		return 0, usage("parse <version>")
+	}
+	n, err := semver.ParseNumber(args[0])
+	if err != nil {
+		return 0, err
+	}
+	fmt.Fprintf(stdout, "major: %d\nminor: %d\npatch: %d\nint: %d\nhex: %#08x\n", n.Major(), n.Minor(), n.Patch(), uint32(n), uint32(n))
+	return exitOK, nil
+}
+
+func bump(args []string, _ io.Reader, stdout io.Writer) (int, error) {
+	if len(args) != 2 {
+		return 0, usage("bump major|minor|patch <version>")
+	}
+
+	p, ok := part(args[0])
+	if !ok {
+		return 0, usage("bump major|minor|patch <version>")
+	}
+
+	n, err := semver.ParseNumber(args[1])
+	if err != nil {
+		return 0, err
+	}
+	if n, err = n.Bump(p); err != nil {
+		return 0, err
+	}
+	fmt.Fprintln(stdout, n.GoString())
+	return exitOK, nil
+}
+
+func compare(args []string, _ io.Reader, stdout io.Writer) (int, error) {
+	if len(args) != 2 {
+		return 0, usage("compare <a> <b>")
+	}
+	a, err := semver.ParseNumber(args[0])
+	if err != nil {
+		return 0, err
+	}
+	b, err := semver.ParseNumber(args[1])
+	if err != nil {
+		return 0, err
+	}
+
+	c := semver.Compare(a, b)
+	fmt.Fprintln(stdout, c)
+	switch c {
+	case -1:
+		return exitLower, nil
+	case 1:
+		return exitGreater, nil
+	}
+	return exitOK, nil
+}
+
+func sortCmd(args []string, stdin io.Reader, stdout io.Writer) (int, error) {
+	fs := flag.NewFlagSet("sort", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	reverse := fs.Bool("r", false, "sort in descending order")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return 0, usage("sort [-r] < versions")
+	}
+
+	ns, err := numbers(nil, stdin)
+	if err != nil {
+		return 0, err
+	}
+	if *reverse {
+		sort.Sort(sort.Reverse(ns))
+	} else {
+		sort.Sort(ns)
+	}
+	for _, n := range ns {
+		fmt.Fprintln(stdout, n.GoString())
+	}
+	return exitOK, nil
+}
+
+func maxCmd(args []string, stdin io.Reader, stdout io.Writer) (int, error) {
+	ns, err := numbers(args, stdin)
+	if err != nil {
+		return 0, err
+	}
+	if len(ns) == 0 {
+		return 0, usage("max [versions...]")
+	}
+
+	m := ns[0]
+	for _, n := range ns[1:] {
+		if n > m {
+			m = n
+		}
+	}
+	fmt.Fprintln(stdout, m.GoString())
+	return exitOK, nil
+}
+
+func satisfies(args []string, stdin io.Reader, stdout io.Writer) (int, error) {
+	if len(args) == 0 {
+		return 0, usage("satisfies <constraint> [versions...]")
+	}
+	rs, err := semver.ParseRanges(args[0])
+	if err != nil {
+		return 0, err
+	}
+	ns, err := numbers(args[1:], stdin)
+	if err != nil {
+		return 0, err
+	}
+
+	code := exitOK
+	for _, n := range ns {
+		if rs.Contains(n) {
+			fmt.Fprintln(stdout, n.GoString())
+		} else {
+			code = exitFalse
+		}
+	}
+	return code, nil
+}
+
+func format(args []string, _ io.Reader, stdout io.Writer) (int, error) {
+	fs := flag.NewFlagSet("format", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Bool("canonical", false, "major.minor.patch (default)")
+	short := fs.Bool("short", false, "without trailing zero components")
+	integer := fs.Bool("int", false, "as a packed integer")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || fs.NFlag() > 1 {
+		return 0, usage("format [--canonical|--short|--int] <version>")
+	}
+
+	n, err := semver.ParseNumber(fs.Arg(0))
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case *short:
+		fmt.Fprintln(stdout, n.String())
+	case *integer:
+		fmt.Fprintln(stdout, uint32(n))
+	default:
+		fmt.Fprintln(stdout, n.GoString())
+	}
+	return exitOK, nil
+}
+
+func file(args []string, _ io.Reader, stdout io.Writer) (int, error) {
+	fs := flag.NewFlagSet("file", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	dryRun := fs.Bool("n", false, "print the diff without writing the files")
+	if err := fs.Parse(args); err != nil || fs.NArg() < 2 {
+		return 0, usage("file [-n] major|minor|patch <file>...")
+	}
+	p, ok := part(fs.Arg(0))
+	if !ok {
+		return 0, usage("file [-n] major|minor|patch <file>...")
+	}
+
+	for _, path := range fs.Args()[1:] {
+		r, err := versionfile.Bump(path, p, *dryRun)
+		if err != nil {
+			return 0, err
+		}
+		if *dryRun {
+			fmt.Fprint(stdout, r.Diff)
+		} else {
+			fmt.Fprintf(stdout, "%s: %s -> %s\n", r.Path, r.Old.GoString(), r.New.GoString())
+		}
+	}
+	return exitOK, nil
+}
+
+// part parses the name of a version component.
+func part(s string) (semver.Part, bool) {
+	switch s {
+	case "major":
+		return semver.PartMajor, true
+	case "minor":
+		return semver.PartMinor, true
+	case "patch":
+		return semver.PartPatch, true
+	}
+	return 0, false
+}
+
+// numbers parses args or, if there are none, the lines read from stdin.
+func numbers(args []string, stdin io.Reader) (semver.Numbers, error) {
+	if len(args) == 0 {
+		s := bufio.NewScanner(stdin)
+		for s.Scan() {
+			if line := strings.TrimSpace(s.Text()); line != "" {
+				args = append(args, line)
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	ns := make(semver.Numbers, len(args))
+	for i, arg := range args {
+		n, err := semver.ParseNumber(arg)
+		if err != nil {
+			return nil, err
+		}
+		ns[i] = n
+	}
+	return ns, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var tcs = []struct {
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{[]string{"parse", "1.2.3"}, "", exitOK, "major: 1\nminor: 2\npatch: 3\nint: 66051\nhex: 0x00010203\n", ""},
+		{[]string{"parse", "1.2.x"}, "", exitInvalidCharacter, "", "semver: invalid character 'x' in: \"1.2.x\"\n"},
+		{[]string{"parse", ""}, "", exitEmpty, "", "semver: number representation is empty\n"},
+		{[]string{"parse"}, "", exitUsage, "", "usage: semver32 parse|bump|compare|sort|max|satisfies|format|file [arguments]\n\tsemver32 parse <version>\n"},
+		{[]string{"bump", "minor", "1.2.3"}, "", exitOK, "1.3.0\n", ""},
+		{[]string{"bump", "major", "65535"}, "", exitMajorTooBig, "", "semver: major component is too big: \"65536\"\n"},
+		{[]string{"bump", "minor", "1.255"}, "", exitMinorTooBig, "", "semver: minor component is too big: \"256\"\n"},
+		{[]string{"bump", "patch", "1.0.255"}, "", exitPatchTooBig, "", "semver: patch component is too big: \"256\"\n"},
+		{[]string{"bump", "build", "1.0"}, "", exitUsage, "", ""},
+		{[]string{"compare", "1.2", "1.2.0"}, "", exitOK, "0\n", ""},
+		{[]string{"compare", "1.2", "1.10"}, "", exitLower, "-1\n", ""},
+		{[]string{"compare", "2", "1.10"}, "", exitGreater, "1\n", ""},
+		{[]string{"compare", "2", "a"}, "", exitInvalidCharacter, "", ""},
+		{[]string{"sort"}, "1.10\n\n1.2\n 0.9.1 \n", exitOK, "0.9.1\n1.2.0\n1.10.0\n", ""},
+		{[]string{"sort", "-r"}, "1.10\n1.2\n0.9.1\n", exitOK, "1.10.0\n1.2.0\n0.9.1\n", ""},
+		{[]string{"sort", "1.2"}, "", exitUsage, "", ""},
+		{[]string{"sort"}, "1.2\n1.256\n", exitMinorTooBig, "", ""},
+		{[]string{"max", "1.2", "1.10", "0.9"}, "", exitOK, "1.10.0\n", ""},
+		{[]string{"max"}, "1.2\n3\n", exitOK, "3.0.0\n", ""},
+		{[]string{"max"}, "", exitUsage, "", ""},
+		{[]string{"satisfies", "^1.2", "1.2.5", "1.9"}, "", exitOK, "1.2.5\n1.9.0\n", ""},
+		{[]string{"satisfies", "^1.2"}, "1.1\n1.3\n2.0\n", exitFalse, "1.3.0\n", ""},
+		{[]string{"satisfies", "^1.a", "1.0"}, "", exitInvalidCharacter, "", ""},
+		{[]string{"format", "1.2"}, "", exitOK, "1.2.0\n", ""},
+		{[]string{"format", "--canonical", "1.2"}, "", exitOK, "1.2.0\n", ""},
+		{[]string{"format", "--short", "1.2.0"}, "", exitOK, "1.2\n", ""},
+		{[]string{"format", "--int", "1.2.0"}, "", exitOK, "66048\n", ""},
+		{[]string{"format", "--int", "--short", "1.2.0"}, "", exitUsage, "", ""},
+		{[]string{"format", "--hex", "1.2.0"}, "", exitUsage, "", ""},
+		{[]string{}, "", exitUsage, "", ""},
+		{[]string{"help"}, "", exitUsage, "", ""},
+	}
+
+	for i, tc := range tcs {
+		var stdout, stderr bytes.Buffer
+		if code := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr); code != tc.code {
+			t.Errorf("tc[%d] %v exit code mismatch expected: %d got: %d (%s)", i, tc.args, tc.code, code, stderr.String())
+		}
+		if got := stdout.String(); got != tc.stdout {
+			t.Errorf("tc[%d] %v stdout mismatch expected: %q got: %q", i, tc.args, tc.stdout, got)
+		}
+		if got := stderr.String(); tc.stderr != "" && got != tc.stderr {
+			t.Errorf("tc[%d] %v stderr mismatch expected: %q got: %q", i, tc.args, tc.stderr, got)
+		}
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "semver32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	version := filepath.Join(dir, "VERSION")
+	pkg := filepath.Join(dir, "package.json")
+	if err := ioutil.WriteFile(version, []byte("v1.2.3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(pkg, []byte("{\"version\": \"1.2.3\"}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var tcs = []struct {
+		args   []string
+		code   int
+		stdout string
+	}{
+		{[]string{"file", "-n", "minor", version}, exitOK, "--- a/" + version + "\n+++ b/" + version + "\n@@ -1 +1 @@\n-v1.2.3\n+v1.3.0\n"},
+		{[]string{"file", "minor", version, pkg}, exitOK, version + ": 1.2.3 -> 1.3.0\n" + pkg + ": 1.2.3 -> 1.3.0\n"},
+		{[]string{"file", "build", version}, exitUsage, ""},
+		{[]string{"file", "minor"}, exitUsage, ""},
+		{[]string{"file", "minor", filepath.Join(dir, "missing.json")}, exitError, ""},
+	}
+
+	for i, tc := range tcs {
+		var stdout, stderr bytes.Buffer
+		if code := run(tc.args, strings.NewReader(""), &stdout, &stderr); code != tc.code {
+			t.Errorf("tc[%d] %v exit code mismatch expected: %d got: %d (%s)", i, tc.args, tc.code, code, stderr.String())
+		}
+		if got := stdout.String(); got != tc.stdout {
+			t.Errorf("tc[%d] %v stdout mismatch expected: %q got: %q", i, tc.args, tc.stdout, got)
+		}
+	}
+
+	if b, _ := ioutil.ReadFile(version); string(b) != "v1.3.0\n" {
+		t.Errorf("content mismatch expected: %q got: %q", "v1.3.0\n", b)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import "context"
+
+type contextKey struct{}
+
+// WithNumber returns a copy of ctx carrying n.
+func WithNumber(ctx context.Context, n Number) context.Context {
+	return context.WithValue(ctx, contextKey{}, n)
+}
+
+// FromContext returns the Number carried by ctx, if any.
+func FromContext(ctx context.Context) (Number, bool) {
+	n, ok := ctx.Value(contextKey{}).(Number)
+	return n, ok
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"context"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := semver.FromContext(ctx); ok {
+		t.Error("expected no Number in an empty context")
+	}
+
+	n := semver.NewNumber(1, 2, 3)
+	if got, ok := semver.FromContext(semver.WithNumber(ctx, n)); !ok || got != n {
+		t.Errorf("context Number mismatch expected: %s got: %s", n, got)
+	}
+}
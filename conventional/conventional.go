@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package conventional infers the component of a semver.Number to bump
+// from commit messages following the Conventional Commits specification:
+// https://www.conventionalcommits.org/en/v1.0.0/
+package conventional
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// ErrNotConventional is the error wrapped by Parse when
+// a message doesn't follow the Conventional Commits specification.
+var ErrNotConventional = errors.New("conventional: not a conventional commit message")
+
+// Commit is a parsed Conventional Commit message.
+type Commit struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	// Breaking is set by a '!' before the colon of the header
+	// or by a "BREAKING CHANGE" footer.
+	Breaking bool
+}
+
+// Parse takes a commit message, parses it and
+// returns a Commit if parsing was successful.
+func Parse(msg string) (Commit, error) {
+	msg = strings.TrimSpace(strings.Replace(msg, "\r\n", "\n", -1))
+	header, body := msg, ""
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		header, body = msg[:i], strings.TrimSpace(msg[i+1:])
+	}
+
+	var c Commit
+	i := strings.Index(header, ": ")
+	if i <= 0 {
+		return c, fmt.Errorf("%w: %q", ErrNotConventional, header)
+	}
+	prefix := header[:i]
+	c.Description = strings.TrimSpace(header[i+2:])
+
+	if strings.HasSuffix(prefix, "!") {
+		prefix, c.Breaking = prefix[:len(prefix)-1], true
+	}
+	if j := strings.IndexByte(prefix, '('); j >= 0 {
+		if !strings.HasSuffix(prefix, ")") || j+2 == len(prefix) {
+			return c, fmt.Errorf("%w: %q", ErrNotConventional, header)
+		}
+		prefix, c.Scope = prefix[:j], prefix[j+1:len(prefix)-1]
+	}
+	if prefix == "" || c.Description == "" || strings.Trim(prefix, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-") != "" {
+		return c, fmt.Errorf("%w: %q", ErrNotConventional, header)
+	}
+	c.Type = prefix
+	c.Body = body
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			c.Breaking = true
+		}
+	}
+	return c, nil
+}
+
+// Bump returns the component to bump for the Commit:
+// the major one for breaking changes, the minor one for features,
+// the patch one for fixes and the zero Part otherwise.
+func (c Commit) Bump() semver.Part {
+	switch {
+	case c.Breaking:
+		return semver.PartMajor
+	case strings.EqualFold(c.Type, "feat"):
+		return semver.PartMinor
+	case strings.EqualFold(c.Type, "fix"):
+		return semver.PartPatch
+	}
+	return 0
+}
+
+// Bump returns the most significant component to bump for the messages.
+// Messages that don't follow the specification are ignored.
+func Bump(msgs []string) semver.Part {
+	var p semver.Part
+	for _, msg := range msgs {
+		c, err := Parse(msg)
+		if b := c.Bump(); err == nil && b != 0 && (p == 0 || b < p) {
+			p = b
+		}
+	}
+	return p
+}
+
+// NextFromCommits returns the Number that follows current once the
+// messages are released; current itself if none of them requires a bump.
+//
+// Before 1.0.0, breaking changes bump the minor component
+// instead of the major one.
+func NextFromCommits(current semver.Number, msgs []string) (semver.Number, error) {
+	p := Bump(msgs)
+	if p == semver.PartMajor && current.Major() == 0 {
+		p = semver.PartMinor
+	}
+	return current.Bump(p)
+}
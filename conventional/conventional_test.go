@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package conventional_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/conventional"
+)
+
+func TestParse(t *testing.T) {
+	var tcs = []struct {
+		msg              string
+		typ, scope, desc string
+		breaking         bool
+		bump             semver.Part
+	}{
+		{"feat: add search", "feat", "", "add search", false, semver.PartMinor},
+		{"fix(api): handle nil", "fix", "api", "handle nil", false, semver.PartPatch},
+		{"FEAT(ui)!: redesign", "FEAT", "ui", "redesign", true, semver.PartMajor},
+		{"refactor!: drop Go 1.14", "refactor", "", "drop Go 1.14", true, semver.PartMajor},
+		{"docs: typo", "docs", "", "typo", false, 0},
+		{"chore(deps): bump yaml\n\nBREAKING CHANGE: needs Go 1.15", "chore", "deps", "bump yaml", true, semver.PartMajor},
+		{"fix: x\r\n\r\nRefs: #12\r\nBREAKING-CHANGE: y", "fix", "", "x", true, semver.PartMajor},
+		{"fix: x\n\nsome BREAKING CHANGE: mentioned", "fix", "", "x", false, semver.PartPatch},
+	}
+
+	for i, tc := range tcs {
+		c, err := conventional.Parse(tc.msg)
+		if err != nil {
+			t.Errorf("tc[%d] no parsing error expected got: %s", i, err.Error())
+			continue
+		}
+		if c.Type != tc.typ || c.Scope != tc.scope || c.Description != tc.desc || c.Breaking != tc.breaking {
+			t.Errorf("tc[%d] commit mismatch expected: %s(%s) %s %t got: %s(%s) %s %t", i, tc.typ, tc.scope, tc.desc, tc.breaking, c.Type, c.Scope, c.Description, c.Breaking)
+		}
+		if b := c.Bump(); b != tc.bump {
+			t.Errorf("tc[%d] bump mismatch expected: %s got: %s", i, tc.bump, b)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for i, msg := range []string{
+		"",
+		"add search",
+		"feat add search",
+		"feat:add search",
+		": add search",
+		"feat: ",
+		"feat(): add search",
+		"feat(api: add search",
+		"new feature: add search",
+		"Merge branch 'main'",
+	} {
+		if _, err := conventional.Parse(msg); !errors.Is(err, conventional.ErrNotConventional) {
+			t.Errorf("tc[%d] not conventional error expected got: %v", i, err)
+		}
+	}
+}
+
+func TestNextFromCommits(t *testing.T) {
+	var tcs = []struct {
+		current string
+		msgs    []string
+		exp     string
+	}{
+		{"1.2.3", nil, "1.2.3"},
+		{"1.2.3", []string{"docs: typo", "Merge branch 'main'"}, "1.2.3"},
+		{"1.2.3", []string{"fix: a", "docs: b"}, "1.2.4"},
+		{"1.2.3", []string{"fix: a", "feat: b", "fix: c"}, "1.3"},
+		{"1.2.3", []string{"fix: a", "feat!: b", "feat: c"}, "2"},
+		{"0.2.3", []string{"fix: a", "feat!: b"}, "0.3"},
+		{"0.2.3", []string{"feat: b"}, "0.3"},
+		{"0.2.3", []string{"fix: b"}, "0.2.4"},
+	}
+
+	for i, tc := range tcs {
+		current, _ := semver.ParseNumber(tc.current)
+		if n, err := conventional.NextFromCommits(current, tc.msgs); err != nil {
+			t.Errorf("tc[%d] no error expected got: %s", i, err.Error())
+		} else if got := n.String(); got != tc.exp {
+			t.Errorf("tc[%d] next mismatch expected: %s got: %s", i, tc.exp, got)
+		}
+	}
+
+	var te semver.ErrorMinorTooBig
+	if _, err := conventional.NextFromCommits(semver.NewNumber(1, 255, 0), []string{"feat: a"}); !errors.As(err, &te) {
+		t.Errorf("minor too big error expected got: %v", err)
+	}
+}
+
+func ExampleNextFromCommits() {
+	n, err := conventional.NextFromCommits(semver.NewNumber(1, 4, 2), []string{
+		"fix(api): handle empty bodies",
+		"feat: add streaming",
+		"docs: document streaming",
+	})
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(n)
+	}
+	// Output: 1.5
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package ecosystem translates the version constraint dialects of several
+// package ecosystems into semver.Ranges and renders semver.Ranges back
+// into each of them.
+//
+// The supported dialects are npm, Cargo, PEP 440 (Python), Maven and
+// RubyGems. Features of a dialect which can't be represented by 32 bit
+// versions, such as prereleases, PEP 440 epochs and local versions or
+// Maven qualifiers, are reported with an *UnsupportedError.
+package ecosystem
+
+import (
+	"fmt"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Dialect identifies the constraint syntax of a package ecosystem.
+type Dialect byte
+
+const (
+	// NPM constraints, e.g. "^1.2 || >=3.0.0 <3.4.0".
+	NPM Dialect = iota + 1
+	// Cargo constraints, where a bare version means caret, e.g. "1.2, <1.8".
+	Cargo
+	// PEP440 constraints used by Python packages, e.g. "~=1.2, !=1.3.1".
+	PEP440
+	// Maven version ranges, e.g. "[1.0,2.0),[3.0,)".
+	Maven
+	// RubyGems requirements, e.g. "~> 1.2, >= 1.2.3".
+	RubyGems
+)
+
+// String satisfies the fmt.Stringer interface.
+func (d Dialect) String() string {
+	switch d {
+	case NPM:
+		return "npm"
+	case Cargo:
+		return "cargo"
+	case PEP440:
+		return "pep440"
+	case Maven:
+		return "maven"
+	case RubyGems:
+		return "rubygems"
+	}
+	return "unknown"
+}
+
+// SyntaxError is the error produced for malformed constraints.
+type SyntaxError struct {
+	Dialect    Dialect
+	Constraint string
+}
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("ecosystem: invalid %s constraint: %q", e.Dialect, e.Constraint)
+}
+
+// UnsupportedError is the error produced for constraints using features
+// of a dialect which 32 bit versions can't represent, and when rendering
+// Ranges a dialect can't express.
+type UnsupportedError struct {
+	Dialect    Dialect
+	Constraint string
+	Feature    string
+}
+
+// Error satisfies the error interface.
+func (e *UnsupportedError) Error() string {
+	if e.Constraint == "" {
+		return fmt.Sprintf("ecosystem: %s can't express %s", e.Dialect, e.Feature)
+	}
+	return fmt.Sprintf("ecosystem: %s %s are not supported: %q", e.Dialect, e.Feature, e.Constraint)
+}
+
+// Parse translates the constraint s, written in the dialect d, into Ranges.
+func Parse(d Dialect, s string) (semver.Ranges, error) {
+	switch d {
+	case NPM:
+		return parseNPM(s)
+	case Cargo:
+		return parseCargo(s)
+	case PEP440:
+		return parsePEP440(s)
+	case Maven:
+		return parseMaven(s)
+	case RubyGems:
+		return parseRubyGems(s)
+	}
+	return nil, fmt.Errorf("ecosystem: unknown dialect: %d", d)
+}
+
+// syntax is the spelling of the comparators of a dialect.
+type syntax struct {
+	eq, ne, ge, lt, le string
+	// any matches every version, none matches no version at all.
+	any, none string
+	// and separates comparators, or separates sets of them, if supported.
+	and, or string
+}
+
+var syntaxes = map[Dialect]syntax{
+	NPM:      {"", "", ">=", "<", "<=", "*", "<0.0.0", " ", " || "},
+	Cargo:    {"=", "", ">=", "<", "<=", "*", "<0.0.0", ", ", ""},
+	PEP440:   {"==", "!=", ">=", "<", "<=", ">=0.0.0", "<0.0.0", ", ", ""},
+	RubyGems: {"= ", "!= ", ">= ", "< ", "<= ", ">= 0.0.0", "< 0.0.0", ", ", ""},
+}
+
+// Format renders the Ranges in the dialect d.
+//
+// Dialects without a union operator, Cargo, PEP 440 and RubyGems, can
+// only express Ranges whose gaps are single versions, excluded with "!=",
+// which Cargo lacks.
+func Format(d Dialect, rs semver.Ranges) (string, error) {
+	rs = semver.NewRanges(rs...)
+	if d == Maven {
+		return formatMaven(rs), nil
+	}
+	syn, ok := syntaxes[d]
+	if !ok {
+		return "", fmt.Errorf("ecosystem: unknown dialect: %d", d)
+	}
+	if len(rs) == 0 {
+		return syn.none, nil
+	}
+
+	if syn.or != "" {
+		sets := make([]string, len(rs))
+		for i, r := range rs {
+			sets[i] = strings.Join(comparators(syn, r), syn.and)
+		}
+		return strings.Join(sets, syn.or), nil
+	}
+
+	cs := comparators(syn, semver.Range{Min: rs[0].Min, Max: rs[len(rs)-1].Max})
+	for i := 1; i < len(rs); i++ {
+		gap := rs[i-1].Max + 1
+		if syn.ne == "" || gap != rs[i].Min-1 {
+			return "", &UnsupportedError{Dialect: d, Feature: "the union of " + rs.String()}
+		}
+		cs = append(cs, syn.ne+gap.GoString())
+	}
+	return strings.Join(cs, syn.and), nil
+}
+
+// comparators returns the comparators matching exactly the Range.
+func comparators(syn syntax, r semver.Range) []string {
+	switch {
+	case r.Min == r.Max:
+		return []string{syn.eq + r.Min.GoString()}
+	case r.Min == 0 && r.Max == semver.MaxNumber:
+		return []string{syn.any}
+	}
+
+	var cs []string
+	if r.Min > 0 {
+		cs = append(cs, syn.ge+r.Min.GoString())
+	}
+	switch next := r.Max + 1; {
+	case r.Max == semver.MaxNumber:
+	case next.Patch() == 0:
+		cs = append(cs, syn.lt+next.GoString())
+	default:
+		cs = append(cs, syn.le+r.Max.GoString())
+	}
+	return cs
+}
+
+// formatMaven renders the Ranges as Maven version ranges,
+// e.g. "[1.0.0,2.0.0),[3.0.0,)".
+func formatMaven(rs semver.Ranges) string {
+	if len(rs) == 0 {
+		return "(,0.0.0)"
+	}
+
+	sets := make([]string, len(rs))
+	for i, r := range rs {
+		var b strings.Builder
+		switch {
+		case r.Min == r.Max:
+			b.WriteString("[" + r.Min.GoString() + "]")
+			sets[i] = b.String()
+			continue
+		case r.Min == 0 && r.Max != semver.MaxNumber:
+			b.WriteString("(,")
+		default:
+			b.WriteString("[" + r.Min.GoString() + ",")
+		}
+		switch next := r.Max + 1; {
+		case r.Max == semver.MaxNumber:
+			b.WriteString(")")
+		case next.Patch() == 0:
+			b.WriteString(next.GoString() + ")")
+		default:
+			b.WriteString(r.Max.GoString() + "]")
+		}
+		sets[i] = b.String()
+	}
+	return strings.Join(sets, ",")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package ecosystem_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/ecosystem"
+)
+
+func TestFormat(t *testing.T) {
+	caret := semver.Range{Min: semver.NewNumber(1, 2, 0), Max: semver.NewNumber(1, 255, 255)}
+	upTo := semver.Range{Min: 0, Max: semver.NewNumber(1, 0, 3)}
+	from := semver.Range{Min: semver.NewNumber(3, 0, 0), Max: semver.MaxNumber}
+	exact := semver.Range{Min: semver.NewNumber(1, 3, 1), Max: semver.NewNumber(1, 3, 1)}
+	hole := semver.NewRanges(caret).Intersect(semver.Ranges{exact}.Complement())
+	all := semver.Ranges{{Min: 0, Max: semver.MaxNumber}}
+
+	var tcs = []struct {
+		d   ecosystem.Dialect
+		rs  semver.Ranges
+		exp string
+		err bool
+	}{
+		{ecosystem.NPM, semver.Ranges{caret, from}, ">=1.2.0 <2.0.0 || >=3.0.0", false},
+		{ecosystem.NPM, semver.Ranges{upTo, exact}, "<=1.0.3 || 1.3.1", false},
+		{ecosystem.NPM, all, "*", false},
+		{ecosystem.NPM, nil, "<0.0.0", false},
+
+		{ecosystem.Cargo, semver.Ranges{caret}, ">=1.2.0, <2.0.0", false},
+		{ecosystem.Cargo, semver.Ranges{exact}, "=1.3.1", false},
+		{ecosystem.Cargo, hole, "", true},
+		{ecosystem.Cargo, semver.Ranges{caret, from}, "", true},
+
+		{ecosystem.PEP440, hole, ">=1.2.0, <2.0.0, !=1.3.1", false},
+		{ecosystem.PEP440, all, ">=0.0.0", false},
+		{ecosystem.PEP440, semver.Ranges{caret, from}, "", true},
+
+		{ecosystem.RubyGems, semver.Ranges{upTo}, "<= 1.0.3", false},
+		{ecosystem.RubyGems, hole, ">= 1.2.0, < 2.0.0, != 1.3.1", false},
+
+		{ecosystem.Maven, semver.Ranges{caret, from}, "[1.2.0,2.0.0),[3.0.0,)", false},
+		{ecosystem.Maven, semver.Ranges{upTo, exact}, "(,1.0.3],[1.3.1]", false},
+		{ecosystem.Maven, all, "[0.0.0,)", false},
+		{ecosystem.Maven, nil, "(,0.0.0)", false},
+	}
+
+	for i, tc := range tcs {
+		s, err := ecosystem.Format(tc.d, tc.rs)
+		var ue *ecosystem.UnsupportedError
+		if tc.err != errors.As(err, &ue) {
+			t.Errorf("tc[%d] %s error mismatch expected: %t got: %v", i, tc.d, tc.err, err)
+			continue
+		}
+		if s != tc.exp {
+			t.Errorf("tc[%d] %s mismatch expected: %q got: %q", i, tc.d, tc.exp, s)
+		}
+		if err != nil {
+			continue
+		}
+
+		// the rendered constraint must parse back to the same Ranges.
+		if rs, err := ecosystem.Parse(tc.d, s); err != nil || rs.String() != semver.NewRanges(tc.rs...).String() {
+			t.Errorf("tc[%d] %s round trip mismatch expected: %s got: %s (%v)", i, tc.d, tc.rs, rs, err)
+		}
+	}
+}
+
+func ExampleFormat() {
+	rs, _ := ecosystem.Parse(ecosystem.RubyGems, "~> 2.3, != 2.3.4")
+	for _, d := range []ecosystem.Dialect{ecosystem.NPM, ecosystem.PEP440, ecosystem.Maven} {
+		s, _ := ecosystem.Format(d, rs)
+		fmt.Printf("%s: %s\n", d, s)
+	}
+	// Output:
+	// npm: >=2.3.0 <=2.3.3 || >=2.3.5 <3.0.0
+	// pep440: >=2.3.0, <3.0.0, !=2.3.4
+	// maven: [2.3.0,2.3.3],[2.3.5,3.0.0)
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package ecosystem
+
+import (
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// everything is the Range of every Number.
+var everything = semver.Range{Min: 0, Max: semver.MaxNumber}
+
+func parseNPM(s string) (semver.Ranges, error) {
+	if strings.TrimSpace(s) == "" {
+		return semver.Ranges{everything}, nil
+	}
+
+	var sets []string
+	for _, set := range strings.Split(s, "||") {
+		tokens := strings.Fields(set)
+		for i, tok := range tokens {
+			if tok == "-" {
+				continue
+			}
+			v := strings.TrimLeft(tok, "<>=~^")
+			op := tok[:len(tok)-len(v)]
+			v = strings.TrimLeft(v, "vV")
+			switch {
+			case strings.ContainsRune(v, '+'):
+				return nil, &UnsupportedError{NPM, s, "build metadata"}
+			case strings.ContainsRune(v, '-'):
+				return nil, &UnsupportedError{NPM, s, "prereleases"}
+			}
+			tokens[i] = op + v
+		}
+		sets = append(sets, strings.Join(tokens, " "))
+	}
+	return semver.ParseRanges(strings.Join(sets, " || "))
+}
+
+func parseCargo(s string) (semver.Ranges, error) {
+	if strings.ContainsAny(s, "|!") {
+		return nil, &SyntaxError{Cargo, s}
+	}
+
+	rs := semver.Ranges{everything}
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		switch {
+		case c == "" || len(strings.Fields(c)) > 2:
+			return nil, &SyntaxError{Cargo, s}
+		case strings.ContainsRune(c, '+'):
+			return nil, &UnsupportedError{Cargo, s, "build metadata"}
+		case strings.ContainsRune(c, '-'):
+			return nil, &UnsupportedError{Cargo, s, "prereleases"}
+		case c[0] >= '0' && c[0] <= '9' && !hasWildcard(c):
+			// a bare version, unless a wildcard, is a caret requirement.
+			c = "^" + c
+		}
+
+		r, err := semver.ParseRanges(c)
+		if err != nil {
+			return nil, err
+		}
+		rs = rs.Intersect(r)
+	}
+	return rs, nil
+}
+
+// hasWildcard tells whether any component of the version v is a wildcard.
+func hasWildcard(v string) bool {
+	for _, c := range strings.Split(v, ".") {
+		if c == "*" || c == "x" || c == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePEP440(s string) (semver.Ranges, error) {
+	rs := semver.Ranges{everything}
+	if strings.TrimSpace(s) == "" {
+		return rs, nil
+	}
+
+	for _, spec := range strings.Split(s, ",") {
+		spec = strings.TrimSpace(spec)
+		v := strings.TrimSpace(strings.TrimLeft(spec, "~=!<>"))
+		op := strings.TrimSpace(spec[:len(spec)-len(v)])
+
+		wildcard := strings.HasSuffix(v, ".*")
+		if wildcard {
+			if op != "==" && op != "!=" {
+				return nil, &SyntaxError{PEP440, s}
+			}
+			v = strings.TrimSuffix(v, ".*")
+		}
+		v = strings.TrimLeft(v, "vV")
+
+		switch {
+		case op == "===":
+			return nil, &UnsupportedError{PEP440, s, "arbitrary equality clauses"}
+		case strings.ContainsRune(v, '!'):
+			return nil, &UnsupportedError{PEP440, s, "epochs"}
+		case strings.ContainsRune(v, '+'):
+			return nil, &UnsupportedError{PEP440, s, "local versions"}
+		}
+
+		core, rest := release(v)
+		switch rest = strings.ToLower(rest); {
+		case strings.Contains(rest, "dev"):
+			return nil, &UnsupportedError{PEP440, s, "development releases"}
+		case strings.Contains(rest, "post") || strings.HasPrefix(rest, "-"):
+			return nil, &UnsupportedError{PEP440, s, "post-releases"}
+		case strings.ContainsAny(rest, "abcr"):
+			return nil, &UnsupportedError{PEP440, s, "prereleases"}
+		case rest != "":
+			return nil, &SyntaxError{PEP440, s}
+		}
+		n, err := number(PEP440, s, core)
+		if err != nil {
+			return nil, err
+		}
+
+		var r semver.Ranges
+		switch op {
+		case "==", "!=":
+			r = semver.Ranges{{Min: n, Max: n}}
+			if wildcard {
+				p, err := semver.ParsePattern(core + ".*")
+				if err != nil {
+					return nil, err
+				}
+				r = semver.Ranges{p.Range()}
+			}
+			if op == "!=" {
+				r = r.Complement()
+			}
+		case ">=":
+			r = semver.Ranges{{Min: n, Max: semver.MaxNumber}}
+		case "<=":
+			r = semver.Ranges{{Min: 0, Max: n}}
+		case ">":
+			r = above(n)
+		case "<":
+			r = below(n)
+		case "~=":
+			// ~=1.2 is >=1.2, ==1.* and ~=1.2.3 is >=1.2.3, ==1.2.*
+			switch strings.Count(core, ".") {
+			case 1:
+				r = semver.Ranges{{Min: n, Max: semver.NewPattern(n, semver.PartMajor).Range().Max}}
+			case 2:
+				r = semver.Ranges{{Min: n, Max: semver.NewPattern(n, semver.PartMinor).Range().Max}}
+			default:
+				return nil, &SyntaxError{PEP440, s}
+			}
+		default:
+			return nil, &SyntaxError{PEP440, s}
+		}
+		rs = rs.Intersect(r)
+	}
+	return rs, nil
+}
+
+// parseMaven parses Maven version ranges. A bare version, which Maven
+// takes as a soft requirement, is taken as the version it recommends.
+func parseMaven(s string) (semver.Ranges, error) {
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return nil, &SyntaxError{Maven, s}
+	}
+	if rest[0] != '[' && rest[0] != '(' {
+		n, err := mavenNumber(s, rest)
+		if err != nil {
+			return nil, err
+		}
+		return semver.Ranges{{Min: n, Max: n}}, nil
+	}
+
+	var union semver.Ranges
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if (rest[0] != '[' && rest[0] != '(') || end < 0 {
+			return nil, &SyntaxError{Maven, s}
+		}
+		left, body, right := rest[0], rest[1:end], rest[end]
+
+		if rest = strings.TrimSpace(rest[end+1:]); rest != "" {
+			if rest[0] != ',' {
+				return nil, &SyntaxError{Maven, s}
+			}
+			if rest = strings.TrimSpace(rest[1:]); rest == "" {
+				return nil, &SyntaxError{Maven, s}
+			}
+		}
+
+		bounds := strings.Split(body, ",")
+		switch len(bounds) {
+		case 1:
+			if left != '[' || right != ']' {
+				return nil, &SyntaxError{Maven, s}
+			}
+			n, err := mavenNumber(s, strings.TrimSpace(body))
+			if err != nil {
+				return nil, err
+			}
+			union = append(union, semver.Range{Min: n, Max: n})
+		case 2:
+			r := semver.Ranges{everything}
+			if lo := strings.TrimSpace(bounds[0]); lo != "" {
+				n, err := mavenNumber(s, lo)
+				if err != nil {
+					return nil, err
+				}
+				if left == '(' {
+					r = r.Intersect(above(n))
+				} else {
+					r = r.Intersect(semver.Ranges{{Min: n, Max: semver.MaxNumber}})
+				}
+			}
+			if hi := strings.TrimSpace(bounds[1]); hi != "" {
+				n, err := mavenNumber(s, hi)
+				if err != nil {
+					return nil, err
+				}
+				if right == ')' {
+					r = r.Intersect(below(n))
+				} else {
+					r = r.Intersect(semver.Ranges{{Min: 0, Max: n}})
+				}
+			}
+			union = append(union, r...)
+		default:
+			return nil, &SyntaxError{Maven, s}
+		}
+	}
+	return semver.NewRanges(union...), nil
+}
+
+func mavenNumber(s, v string) (semver.Number, error) {
+	core, rest := release(v)
+	if rest != "" {
+		return 0, &UnsupportedError{Maven, s, "qualifiers"}
+	}
+	return number(Maven, s, core)
+}
+
+func parseRubyGems(s string) (semver.Ranges, error) {
+	rs := semver.Ranges{everything}
+	for _, req := range strings.Split(s, ",") {
+		req = strings.TrimSpace(req)
+		v := strings.TrimSpace(strings.TrimLeft(req, "=!<>~"))
+		op := strings.TrimSpace(req[:len(req)-len(v)])
+
+		core, rest := release(v)
+		if rest != "" {
+			if strings.IndexFunc(rest, isLetter) >= 0 {
+				return nil, &UnsupportedError{RubyGems, s, "prereleases"}
+			}
+			return nil, &SyntaxError{RubyGems, s}
+		}
+		n, err := number(RubyGems, s, core)
+		if err != nil {
+			return nil, err
+		}
+
+		var r semver.Ranges
+		switch op {
+		case "", "=":
+			r = semver.Ranges{{Min: n, Max: n}}
+		case "!=":
+			r = semver.Ranges{{Min: n, Max: n}}.Complement()
+		case ">=":
+			r = semver.Ranges{{Min: n, Max: semver.MaxNumber}}
+		case "<=":
+			r = semver.Ranges{{Min: 0, Max: n}}
+		case ">":
+			r = above(n)
+		case "<":
+			r = below(n)
+		case "~>":
+			// ~> 1.2 is >= 1.2, < 2 and ~> 1.2.3 is >= 1.2.3, < 1.3
+			p := semver.PartMajor
+			if strings.Count(core, ".") == 2 {
+				p = semver.PartMinor
+			}
+			r = semver.Ranges{{Min: n, Max: semver.NewPattern(n, p).Range().Max}}
+		default:
+			return nil, &SyntaxError{RubyGems, s}
+		}
+		rs = rs.Intersect(r)
+	}
+	return rs, nil
+}
+
+// release splits v into its leading release segments, digits
+// separated by dots, and whatever follows them.
+func release(v string) (string, string) {
+	i := 0
+	for i < len(v) && (v[i] >= '0' && v[i] <= '9' || v[i] == '.') {
+		i++
+	}
+	return v[:i], v[i:]
+}
+
+// number parses the release segments of a version of the constraint s.
+func number(d Dialect, s, core string) (semver.Number, error) {
+	if core == "" || strings.HasPrefix(core, ".") || strings.HasSuffix(core, ".") || strings.Contains(core, "..") {
+		return 0, &SyntaxError{d, s}
+	}
+	if strings.Count(core, ".") > 2 {
+		return 0, &UnsupportedError{d, s, "versions with more than three components"}
+	}
+	return semver.ParseNumber(core)
+}
+
+// above returns the Numbers greater than n.
+func above(n semver.Number) semver.Ranges {
+	if n == semver.MaxNumber {
+		return semver.Ranges{}
+	}
+	return semver.Ranges{{Min: n + 1, Max: semver.MaxNumber}}
+}
+
+// below returns the Numbers lower than n.
+func below(n semver.Number) semver.Ranges {
+	if n == 0 {
+		return semver.Ranges{}
+	}
+	return semver.Ranges{{Min: 0, Max: n - 1}}
+}
+
+func isLetter(r rune) bool { return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' }
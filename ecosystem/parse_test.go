@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package ecosystem_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vilarfg/go-semver32/ecosystem"
+)
+
+const (
+	ok = iota
+	syntax
+	unsupported
+	other
+)
+
+func TestParse(t *testing.T) {
+	var tcs = []struct {
+		d   ecosystem.Dialect
+		s   string
+		exp string
+		err int
+	}{
+		{ecosystem.NPM, "^1.2 || >=3.0.0 <3.4.0", "1.2.0 - 1.255.255 || 3.0.0 - 3.3.255", ok},
+		{ecosystem.NPM, "~v1.2.3", "1.2.3 - 1.2.255", ok},
+		{ecosystem.NPM, "1.2.3 - 2", "1.2.3 - 2.255.255", ok},
+		{ecosystem.NPM, "", "0.0.0 - 65535.255.255", ok},
+		{ecosystem.NPM, "1.x", "1.0.0 - 1.255.255", ok},
+		{ecosystem.NPM, "^1.2.3-beta.1", "", unsupported},
+		{ecosystem.NPM, "1.2.3+build", "", unsupported},
+		{ecosystem.NPM, "^1.2.a", "", other},
+
+		{ecosystem.Cargo, "1.2", "1.2.0 - 1.255.255", ok},
+		{ecosystem.Cargo, "0.2.3", "0.2.3 - 0.2.255", ok},
+		{ecosystem.Cargo, "=1.2", "1.2.0 - 1.2.255", ok},
+		{ecosystem.Cargo, ">= 1.2, < 1.5", "1.2.0 - 1.4.255", ok},
+		{ecosystem.Cargo, "~1", "1.0.0 - 1.255.255", ok},
+		{ecosystem.Cargo, "1.*", "1.0.0 - 1.255.255", ok},
+		{ecosystem.Cargo, "1.2.*", "1.2.0 - 1.2.255", ok},
+		{ecosystem.Cargo, "1.2.x", "1.2.0 - 1.2.255", ok},
+		{ecosystem.Cargo, "0.*", "0.0.0 - 0.255.255", ok},
+		{ecosystem.Cargo, "*", "0.0.0 - 65535.255.255", ok},
+		{ecosystem.Cargo, "1.2 || 2", "", syntax},
+		{ecosystem.Cargo, "!=1.2", "", syntax},
+		{ecosystem.Cargo, "1.2,", "", syntax},
+		{ecosystem.Cargo, "1.2 - 1.4", "", syntax},
+		{ecosystem.Cargo, "1.2.3-alpha", "", unsupported},
+
+		{ecosystem.PEP440, "~=1.2, !=1.3.1", "1.2.0 - 1.3.0 || 1.3.2 - 1.255.255", ok},
+		{ecosystem.PEP440, "~=1.2.3", "1.2.3 - 1.2.255", ok},
+		{ecosystem.PEP440, "==1.2", "1.2.0 - 1.2.0", ok},
+		{ecosystem.PEP440, "==1.2.*", "1.2.0 - 1.2.255", ok},
+		{ecosystem.PEP440, "!=1.*", "0.0.0 - 0.255.255 || 2.0.0 - 65535.255.255", ok},
+		{ecosystem.PEP440, ">1.2,<=v2", "1.2.1 - 2.0.0", ok},
+		{ecosystem.PEP440, "<0", "<0.0.0", ok},
+		{ecosystem.PEP440, " ", "0.0.0 - 65535.255.255", ok},
+		{ecosystem.PEP440, "~=1", "", syntax},
+		{ecosystem.PEP440, ">=1.*", "", syntax},
+		{ecosystem.PEP440, "1.2", "", syntax},
+		{ecosystem.PEP440, "==1.2$", "", syntax},
+		{ecosystem.PEP440, ">=1!2.0", "", unsupported},
+		{ecosystem.PEP440, "==1.2+ubuntu1", "", unsupported},
+		{ecosystem.PEP440, ">=1.2rc1", "", unsupported},
+		{ecosystem.PEP440, ">=1.2.post1", "", unsupported},
+		{ecosystem.PEP440, ">=1.2.dev3", "", unsupported},
+		{ecosystem.PEP440, "===1.2", "", unsupported},
+		{ecosystem.PEP440, ">=1.2.3.4", "", unsupported},
+		{ecosystem.PEP440, ">=1.256", "", other},
+
+		{ecosystem.Maven, "[1.0,2.0),[3.0,)", "1.0.0 - 1.255.255 || 3.0.0 - 65535.255.255", ok},
+		{ecosystem.Maven, "(,1.0]", "0.0.0 - 1.0.0", ok},
+		{ecosystem.Maven, "(1.0, 1.1)", "1.0.1 - 1.0.255", ok},
+		{ecosystem.Maven, "[1.5]", "1.5.0 - 1.5.0", ok},
+		{ecosystem.Maven, "1.5", "1.5.0 - 1.5.0", ok},
+		{ecosystem.Maven, "(1.0)", "", syntax},
+		{ecosystem.Maven, "[1.0,2.0", "", syntax},
+		{ecosystem.Maven, "[1.0,2.0),", "", syntax},
+		{ecosystem.Maven, "[1,2,3]", "", syntax},
+		{ecosystem.Maven, "[1.0-SNAPSHOT,)", "", unsupported},
+
+		{ecosystem.RubyGems, "~> 1.2, >= 1.2.3", "1.2.3 - 1.255.255", ok},
+		{ecosystem.RubyGems, "~> 1.2.3", "1.2.3 - 1.2.255", ok},
+		{ecosystem.RubyGems, "~> 1", "1.0.0 - 1.255.255", ok},
+		{ecosystem.RubyGems, "1.2", "1.2.0 - 1.2.0", ok},
+		{ecosystem.RubyGems, "> 1, != 1.0.5, < 1.1", "1.0.1 - 1.0.4 || 1.0.6 - 1.0.255", ok},
+		{ecosystem.RubyGems, "=< 1", "", syntax},
+		{ecosystem.RubyGems, ">= 1.0.0.pre", "", unsupported},
+	}
+
+	for i, tc := range tcs {
+		rs, err := ecosystem.Parse(tc.d, tc.s)
+
+		var (
+			se  *ecosystem.SyntaxError
+			ue  *ecosystem.UnsupportedError
+			got = other
+		)
+		switch {
+		case err == nil:
+			got = ok
+		case errors.As(err, &se):
+			got = syntax
+		case errors.As(err, &ue):
+			got = unsupported
+		}
+		if got != tc.err {
+			t.Errorf("tc[%d] %s %q error mismatch expected: %d got: %d (%v)", i, tc.d, tc.s, tc.err, got, err)
+			continue
+		}
+		if err == nil && rs.String() != tc.exp {
+			t.Errorf("tc[%d] %s %q mismatch expected: %s got: %s", i, tc.d, tc.s, tc.exp, rs.String())
+		}
+	}
+}
@@ -67,6 +67,175 @@ func (e ErrorPatchTooBig) Error() string {
 	return "patch component is too big: \"" + string(e) + "\""
 }
 
+// ErrorInvalidLength is an error to signal that the binary
+// representation of a value doesn't have the expected length.
+type ErrorInvalidLength int
+
+// Error satisfies the error interface.
+func (e ErrorInvalidLength) Error() string {
+	return fmt.Sprintf("invalid binary representation length: %d", int(e))
+}
+
+// ErrorNoCommonVersion is an error to signal that two peers
+// do not support any Number in common.
+type ErrorNoCommonVersion struct{ local, remote Ranges }
+
+// Error satisfies the error interface.
+func (e ErrorNoCommonVersion) Error() string {
+	if e.remote == nil {
+		return fmt.Sprintf("no common version with the remote peer for \"%s\"", e.local)
+	}
+	return fmt.Sprintf("no common version between \"%s\" and \"%s\"", e.local, e.remote)
+}
+
+// ErrorHandshake is an error to signal that
+// a version negotiation handshake did not follow the protocol.
+type ErrorHandshake string
+
+// Error satisfies the error interface.
+func (e ErrorHandshake) Error() string { return "handshake failed: " + string(e) }
+
+// ErrorDuplicateFeature is an error to signal that
+// a feature with the same name has already been registered.
+type ErrorDuplicateFeature string
+
+// Error satisfies the error interface.
+func (e ErrorDuplicateFeature) Error() string {
+	return "feature is already registered: \"" + string(e) + "\""
+}
+
+// ErrorFeatureSchedule is an error to signal that a feature is deprecated
+// before it is introduced, or removed before it is introduced or deprecated.
+type ErrorFeatureSchedule string
+
+// Error satisfies the error interface.
+func (e ErrorFeatureSchedule) Error() string {
+	return "feature schedule is out of order: \"" + string(e) + "\""
+}
+
+// ErrorFeatureName is an error to signal that a feature has no name.
+type ErrorFeatureName struct{}
+
+// Error satisfies the error interface.
+func (e ErrorFeatureName) Error() string { return "feature name is empty" }
+
+// ErrorDuplicateMigration is an error to signal that
+// a migration has already been registered under the same Number.
+type ErrorDuplicateMigration string
+
+// Error satisfies the error interface.
+func (e ErrorDuplicateMigration) Error() string {
+	return "migration is already registered: \"" + string(e) + "\""
+}
+
+// ErrorMigrationGap is an error to signal that there are minor lines
+// missing between two migrations, see Migrations.AllowGaps.
+type ErrorMigrationGap struct{ from, to Number }
+
+// Error satisfies the error interface.
+func (e ErrorMigrationGap) Error() string {
+	return fmt.Sprintf("migration gap between \"%#v\" and \"%#v\"", e.from, e.to)
+}
+
+// ErrorIrreversibleMigration is an error to signal that
+// a migration that cannot be reverted would have to be.
+type ErrorIrreversibleMigration string
+
+// Error satisfies the error interface.
+func (e ErrorIrreversibleMigration) Error() string {
+	return "migration cannot be reverted: \"" + string(e) + "\""
+}
+
+// ErrorMigrationFailed is an error to signal that a migration step failed.
+type ErrorMigrationFailed struct {
+	n   Number
+	up  bool
+	err error
+}
+
+// Error satisfies the error interface.
+func (e ErrorMigrationFailed) Error() string {
+	if e.up {
+		return fmt.Sprintf("migration up to \"%#v\" failed: %s", e.n, e.err)
+	}
+	return fmt.Sprintf("migration down from \"%#v\" failed: %s", e.n, e.err)
+}
+
+// Unwrap returns the error produced by the migration step.
+func (e ErrorMigrationFailed) Unwrap() error { return e.err }
+
+// Number returns the Number the failed migration step is registered under.
+func (e ErrorMigrationFailed) Number() Number { return e.n }
+
+// ErrorOverflow is an error to signal that a Number
+// doesn't fit within a platform version field.
+type ErrorOverflow struct {
+	n     Number
+	field string
+}
+
+// Error satisfies the error interface.
+func (e ErrorOverflow) Error() string {
+	return fmt.Sprintf("\"%#v\" overflows %s", e.n, e.field)
+}
+
+// Number returns the Number that doesn't fit.
+func (e ErrorOverflow) Number() Number { return e.n }
+
+// ErrorInvalidVersionCode is an error to signal that an Android
+// versionCode wasn't produced by the VersionCodeScheme decoding it.
+type ErrorInvalidVersionCode int
+
+// Error satisfies the error interface.
+func (e ErrorInvalidVersionCode) Error() string {
+	return fmt.Sprintf("invalid versionCode: %d", int(e))
+}
+
+// ErrorVersionCodeScheme is an error to signal that the multipliers
+// of a VersionCodeScheme are not decreasing or not positive.
+type ErrorVersionCodeScheme string
+
+// Error satisfies the error interface.
+func (e ErrorVersionCodeScheme) Error() string {
+	return "invalid versionCode scheme: \"" + string(e) + "\""
+}
+
+// ErrorInvalidVerb is an error to signal that a value
+// cannot be scanned with the verb.
+type ErrorInvalidVerb rune
+
+// Error satisfies the error interface.
+func (e ErrorInvalidVerb) Error() string {
+	return fmt.Sprintf("invalid verb %%%c", rune(e))
+}
+
+// ErrorPackedTooBig is an error to signal that the packed integer
+// representation of a Number is out of bounds (too big).
+type ErrorPackedTooBig string
+
+// Error satisfies the error interface.
+func (e ErrorPackedTooBig) Error() string {
+	return "packed number is too big: \"" + string(e) + "\""
+}
+
+// ErrorEnv is an error to signal that
+// an environment variable holds an invalid value.
+type ErrorEnv struct {
+	key string
+	err error
+}
+
+// Error satisfies the error interface.
+func (e ErrorEnv) Error() string {
+	return fmt.Sprintf("invalid environment variable %s: %s", e.key, e.err)
+}
+
+// Unwrap returns the error produced parsing the value.
+func (e ErrorEnv) Unwrap() error { return e.err }
+
+// Key returns the name of the environment variable.
+func (e ErrorEnv) Key() string { return e.key }
+
 var (
 	errorEmpty       = &Error{ErrorEmpty{}}
 	errorMajorTooBig = &Error{ErrorMajorTooBig("65536")}
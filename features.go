@@ -0,0 +1,210 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Feature describes the Number a named feature was introduced in and,
+// optionally, the ones it was deprecated and removed in.
+type Feature struct {
+	Name       string  `json:"name" yaml:"name"`
+	Introduced Number  `json:"introduced" yaml:"introduced"`
+	Deprecated *Number `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Removed    *Number `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// Enabled reports whether the Feature is available in the specified Number:
+// it has been introduced and it has not been removed yet.
+func (f Feature) Enabled(n Number) bool {
+	return n >= f.Introduced && (f.Removed == nil || n < *f.Removed)
+}
+
+// IsDeprecated reports whether the Feature is
+// available but deprecated in the specified Number.
+func (f Feature) IsDeprecated(n Number) bool {
+	return f.Enabled(n) && f.Deprecated != nil && n >= *f.Deprecated
+}
+
+func (f Feature) validate() error {
+	if f.Name == "" {
+		return &Error{ErrorFeatureName{}}
+	}
+	last := f.Introduced
+	if f.Deprecated != nil {
+		if *f.Deprecated < last {
+			return &Error{ErrorFeatureSchedule(f.Name)}
+		}
+		last = *f.Deprecated
+	}
+	if f.Removed != nil && (*f.Removed <= f.Introduced || *f.Removed < last) {
+		return &Error{ErrorFeatureSchedule(f.Name)}
+	}
+	return nil
+}
+
+// Features is a registry of Feature values, keyed by their name.
+//
+// The zero value is an empty registry ready to use.
+// It is safe for concurrent use and, thus, must not be copied after use.
+type Features struct {
+	mu sync.RWMutex
+	m  map[string]Feature
+}
+
+// NewFeatures creates a registry holding the specified Feature values.
+func NewFeatures(fs ...Feature) (*Features, error) {
+	r := new(Features)
+	for _, f := range fs {
+		if err := r.Register(f); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds the Feature to the registry.
+//
+// It will produce an error if the Feature has no name, if a Feature with
+// the same name is already registered, or if its schedule is out of order.
+func (r *Features) Register(f Feature) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.m[f.Name]; ok {
+		return &Error{ErrorDuplicateFeature(f.Name)}
+	}
+	if r.m == nil {
+		r.m = make(map[string]Feature)
+	}
+	r.m[f.Name] = f
+	return nil
+}
+
+// Lookup returns the named Feature, if registered.
+func (r *Features) Lookup(name string) (Feature, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.m[name]
+	return f, ok
+}
+
+// Enabled reports whether the named Feature is available in the specified
+// Number. Unregistered features are never enabled.
+func (r *Features) Enabled(name string, n Number) bool {
+	f, ok := r.Lookup(name)
+	return ok && f.Enabled(n)
+}
+
+// Deprecated reports whether the named Feature is
+// available but deprecated in the specified Number.
+func (r *Features) Deprecated(name string, n Number) bool {
+	f, ok := r.Lookup(name)
+	return ok && f.IsDeprecated(n)
+}
+
+// At returns the names of the Feature values
+// available in the specified Number, sorted.
+func (r *Features) At(n Number) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for name, f := range r.m {
+		if f.Enabled(n) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Diff returns the names of the Feature values that become available and
+// the ones that stop being available when going from one Number to another,
+// both sorted.
+func (r *Features) Diff(from, to Number) (added, removed []string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name, f := range r.m {
+		switch was, is := f.Enabled(from), f.Enabled(to); {
+		case !was && is:
+			added = append(added, name)
+		case was && !is:
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// list returns the registered Feature values sorted by name.
+func (r *Features) list() []Feature {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fs := make([]Feature, 0, len(r.m))
+	for _, f := range r.m {
+		fs = append(fs, f)
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i].Name < fs[j].Name })
+	return fs
+}
+
+// load replaces the registered Feature values with the specified ones.
+func (r *Features) load(fs []Feature) error {
+	nr, err := NewFeatures(fs...)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.m = nr.m
+	return nil
+}
+
+// MarshalYAML satisfies the gopkg.in/yaml.v3.Marshaler interface.
+// The registry is represented as a sequence of Feature values.
+func (r *Features) MarshalYAML() (interface{}, error) {
+	return r.list(), nil
+}
+
+// MarshalJSON satisfies the encoding/json.Marshaler interface.
+// The registry is represented as an array of Feature values.
+func (r *Features) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.list())
+}
+
+// UnmarshalYAML satisfies the gopkg.in/yaml.v3.Unmarshaler interface.
+func (r *Features) UnmarshalYAML(value *yaml.Node) error {
+	var fs []Feature
+	if err := value.Decode(&fs); err != nil {
+		return err
+	}
+	return r.load(fs)
+}
+
+// UnmarshalJSON satisfies the encoding/json.Unmarshaler interface.
+func (r *Features) UnmarshalJSON(data []byte) error {
+	var fs []Feature
+	if err := json.Unmarshal(data, &fs); err != nil {
+		return err
+	}
+	return r.load(fs)
+}
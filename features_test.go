@@ -0,0 +1,186 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+const featuresYAML = `
+- name: search
+  introduced: "1.0"
+- name: streaming
+  introduced: "3.2"
+- name: legacy-auth
+  introduced: "1.0"
+  deprecated: "2.0"
+  removed: "3.0"
+- name: bulk
+  introduced: "2.5"
+  deprecated: "3.2"
+`
+
+func loadFeatures(t *testing.T) *semver.Features {
+	var fs semver.Features
+	if err := yaml.Unmarshal([]byte(featuresYAML), &fs); err != nil {
+		t.Fatalf("no yaml unmarshaling error expected got: %s", err.Error())
+	}
+	return &fs
+}
+
+func TestFeatures(t *testing.T) {
+	fs := loadFeatures(t)
+
+	var tcs = []struct {
+		v          string
+		at         string
+		deprecated []string
+	}{
+		{"0.9", "[]", nil},
+		{"1.0", "[legacy-auth search]", nil},
+		{"2.0", "[legacy-auth search]", []string{"legacy-auth"}},
+		{"2.5", "[bulk legacy-auth search]", []string{"legacy-auth"}},
+		{"3.0", "[bulk search]", nil},
+		{"3.2", "[bulk search streaming]", []string{"bulk"}},
+	}
+
+	for i, tc := range tcs {
+		v, _ := semver.ParseNumber(tc.v)
+		if got := fmt.Sprint(fs.At(v)); got != tc.at {
+			t.Errorf("tc[%d] features at %s mismatch expected: %s got: %s", i, v, tc.at, got)
+		}
+		for _, name := range fs.At(v) {
+			if !fs.Enabled(name, v) {
+				t.Errorf("tc[%d] expected %s to be enabled at %s", i, name, v)
+			}
+		}
+		for _, name := range tc.deprecated {
+			if !fs.Deprecated(name, v) {
+				t.Errorf("tc[%d] expected %s to be deprecated at %s", i, name, v)
+			}
+		}
+	}
+
+	if fs.Enabled("missing", semver.MaxNumber) || fs.Deprecated("missing", semver.MaxNumber) {
+		t.Error("expected unregistered features NOT to be enabled nor deprecated")
+	}
+
+	added, removed := fs.Diff(semver.NewNumber(2, 0, 0), semver.NewNumber(3, 2, 0))
+	if exp, got := "[bulk streaming] [legacy-auth]", fmt.Sprint(added, removed); exp != got {
+		t.Errorf("diff mismatch expected: %s got: %s", exp, got)
+	}
+	added, removed = fs.Diff(semver.NewNumber(3, 2, 0), semver.NewNumber(2, 0, 0))
+	if exp, got := "[legacy-auth] [bulk streaming]", fmt.Sprint(added, removed); exp != got {
+		t.Errorf("reversed diff mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func TestFeaturesRegister(t *testing.T) {
+	v := func(s string) *semver.Number {
+		n, _ := semver.ParseNumber(s)
+		return &n
+	}
+
+	var tcs = []struct {
+		f   semver.Feature
+		err error
+	}{
+		{semver.Feature{Name: "a", Introduced: *v("1")}, nil},
+		{semver.Feature{Name: "a", Introduced: *v("2")}, semver.ErrorDuplicateFeature("a")},
+		{semver.Feature{Introduced: *v("2")}, semver.ErrorFeatureName{}},
+		{semver.Feature{Name: "b", Introduced: *v("2"), Deprecated: v("1")}, semver.ErrorFeatureSchedule("b")},
+		{semver.Feature{Name: "c", Introduced: *v("2"), Removed: v("2")}, semver.ErrorFeatureSchedule("c")},
+		{semver.Feature{Name: "d", Introduced: *v("2"), Deprecated: v("3"), Removed: v("2.5")}, semver.ErrorFeatureSchedule("d")},
+		{semver.Feature{Name: "e", Introduced: *v("2"), Deprecated: v("2"), Removed: v("2.5")}, nil},
+	}
+
+	var fs semver.Features
+	for i, tc := range tcs {
+		err := fs.Register(tc.f)
+		if tc.err == nil {
+			if err != nil {
+				t.Errorf("tc[%d] no registration error expected got: %s", i, err.Error())
+			}
+		} else if e, ok := err.(*semver.Error); !ok || e.Unwrap() != tc.err {
+			t.Errorf("tc[%d] registration error mismatch expected: %s got: %v", i, tc.err, err)
+		}
+	}
+
+	if _, err := semver.NewFeatures(tcs[0].f, tcs[1].f); err == nil {
+		t.Error("duplicate feature error expected got: nil")
+	}
+}
+
+func TestFeaturesMarshaling(t *testing.T) {
+	fs := loadFeatures(t)
+
+	b, err := json.Marshal(fs)
+	if err != nil {
+		t.Fatalf("no json marshaling error expected got: %s", err.Error())
+	}
+	var un semver.Features
+	if err := json.Unmarshal(b, &un); err != nil {
+		t.Fatalf("no json unmarshaling error expected got: %s", err.Error())
+	}
+	for _, name := range []string{"search", "streaming", "legacy-auth", "bulk"} {
+		exp, _ := fs.Lookup(name)
+		if got, ok := un.Lookup(name); !ok || !reflect.DeepEqual(exp, got) {
+			t.Errorf("json unmarshaled %s mismatch expected: %+v got: %+v", name, exp, got)
+		}
+	}
+
+	if yb, err := yaml.Marshal(fs); err != nil {
+		t.Errorf("no yaml marshaling error expected got: %s", err.Error())
+	} else if err := yaml.Unmarshal(yb, &un); err != nil {
+		t.Errorf("no yaml unmarshaling error expected got: %s", err.Error())
+	}
+
+	if err := json.Unmarshal([]byte(`[{"name":"a","introduced":"1.x"}]`), &un); err == nil {
+		t.Error("json unmarshaling error expected got: nil")
+	}
+	if err := json.Unmarshal([]byte(`[{"name":"a","introduced":"1"},{"name":"a","introduced":"2"}]`), &un); err == nil {
+		t.Error("json unmarshaling error expected got: nil")
+	}
+}
+
+func TestFeaturesConcurrency(t *testing.T) {
+	fs := loadFeatures(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fs.Register(semver.Feature{Name: fmt.Sprint("f", i), Introduced: semver.NewNumber(uint16(i), 0, 0)})
+			fs.At(semver.NewNumber(4, 0, 0))
+			fs.Enabled("search", semver.NewNumber(1, 0, 0))
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(fs.At(semver.MaxNumber)); got != 11 {
+		t.Errorf("feature count mismatch expected: 11 got: %d", got)
+	}
+}
+
+func ExampleFeatures_Enabled() {
+	fs, err := semver.NewFeatures(semver.Feature{Name: "streaming", Introduced: semver.NewNumber(3, 2, 0)})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(fs.Enabled("streaming", semver.NewNumber(3, 1, 9)), fs.Enabled("streaming", semver.NewNumber(3, 2, 0)))
+	// Output: false true
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"errors"
+	"flag"
+	"os"
+)
+
+// Set satisfies the flag.Value interface.
+func (n *Number) Set(s string) error {
+	nn, err := ParseNumber(s)
+	if err != nil {
+		return err
+	}
+	*n = nn
+	return nil
+}
+
+// Get satisfies the flag.Getter interface.
+func (n *Number) Get() interface{} { return *n }
+
+// NumberVar defines a Number flag with the specified name, default value
+// and usage string in the flag.FlagSet. The argument p points to the
+// Number variable in which to store the value of the flag.
+func NumberVar(fs *flag.FlagSet, p *Number, name string, value Number, usage string) {
+	*p = value
+	fs.Var(p, name, usage)
+}
+
+// Set satisfies the flag.Value interface.
+func (rs *Ranges) Set(s string) error {
+	r, err := ParseRanges(s)
+	if err != nil {
+		return err
+	}
+	*rs = r
+	return nil
+}
+
+// Get satisfies the flag.Getter interface.
+func (rs *Ranges) Get() interface{} { return *rs }
+
+// RangesVar defines a Ranges flag with the specified name, default value
+// and usage string in the flag.FlagSet. The argument p points to the
+// Ranges variable in which to store the value of the flag.
+func RangesVar(fs *flag.FlagSet, p *Ranges, name string, value Ranges, usage string) {
+	*p = value
+	fs.Var(p, name, usage)
+}
+
+// FromEnv returns the Number held by the environment variable key,
+// or def if the variable is unset or empty.
+func FromEnv(key string, def Number) (Number, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	n, err := ParseNumber(s)
+	if err != nil {
+		return 0, &Error{ErrorEnv{key, cause(err)}}
+	}
+	return n, nil
+}
+
+// RangesFromEnv returns the Ranges held by the environment variable key,
+// or def if the variable is unset or empty.
+func RangesFromEnv(key string, def Ranges) (Ranges, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return def, nil
+	}
+	rs, err := ParseRanges(s)
+	if err != nil {
+		return nil, &Error{ErrorEnv{key, cause(err)}}
+	}
+	return rs, nil
+}
+
+// cause returns the error wrapped by err, if it was produced by this package.
+func cause(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.e
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestFlags(t *testing.T) {
+	var tcs = []struct {
+		args       []string
+		minVersion semver.Number
+		constraint string
+		err        bool
+	}{
+		{nil, semver.NewNumber(1, 0, 0), "1.0.0 - 1.255.255", false},
+		{[]string{"--min-version=1.2"}, semver.NewNumber(1, 2, 0), "1.0.0 - 1.255.255", false},
+		{[]string{"-constraint", ">=2 <3 || 4.x", "-min-version", "2.1.3"}, semver.NewNumber(2, 1, 3), "2.0.0 - 2.255.255 || 4.0.0 - 4.255.255", false},
+		{[]string{"--constraint=<0.0.0"}, semver.NewNumber(1, 0, 0), "<0.0.0", false},
+		{[]string{"--min-version=1.a"}, 0, "", true},
+		{[]string{"--constraint=^1.x.2"}, 0, "", true},
+	}
+
+	for i, tc := range tcs {
+		var (
+			fs         = flag.NewFlagSet("test", flag.ContinueOnError)
+			minVersion semver.Number
+			constraint semver.Ranges
+		)
+		fs.SetOutput(ioutil.Discard)
+		semver.NumberVar(fs, &minVersion, "min-version", semver.NewNumber(1, 0, 0), "minimum version")
+		semver.RangesVar(fs, &constraint, "constraint", semver.Ranges{{Min: semver.NewNumber(1, 0, 0), Max: semver.NewNumber(1, 255, 255)}}, "supported versions")
+
+		err := fs.Parse(tc.args)
+		if (err != nil) != tc.err {
+			t.Errorf("tc[%d] %v error mismatch expected: %t got: %v", i, tc.args, tc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if minVersion != tc.minVersion {
+			t.Errorf("tc[%d] min-version mismatch expected: %#v got: %#v", i, tc.minVersion, minVersion)
+		}
+		if constraint.String() != tc.constraint {
+			t.Errorf("tc[%d] constraint mismatch expected: %s got: %s", i, tc.constraint, constraint)
+		}
+		if got := fs.Lookup("min-version").Value.(flag.Getter).Get(); got != tc.minVersion {
+			t.Errorf("tc[%d] getter mismatch expected: %#v got: %#v", i, tc.minVersion, got)
+		}
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	const key = "SEMVER32_TEST_VERSION"
+	defer os.Unsetenv(key)
+	def := semver.NewNumber(1, 0, 0)
+
+	var tcs = []struct {
+		value string
+		set   bool
+		exp   semver.Number
+		msg   string
+	}{
+		{"", false, def, ""},
+		{"", true, def, ""},
+		{"2.3", true, semver.NewNumber(2, 3, 0), ""},
+		{"2.x", true, 0, "semver: invalid environment variable SEMVER32_TEST_VERSION: invalid character 'x' in: \"2.x\""},
+	}
+
+	for i, tc := range tcs {
+		if tc.set {
+			os.Setenv(key, tc.value)
+		} else {
+			os.Unsetenv(key)
+		}
+
+		n, err := semver.FromEnv(key, def)
+		if tc.msg != "" {
+			var (
+				env semver.ErrorEnv
+				ice semver.ErrorInvalidCharacter
+			)
+			if err == nil || err.Error() != tc.msg || !errors.As(err, &env) || env.Key() != key || !errors.As(err, &ice) {
+				t.Errorf("tc[%d] error mismatch expected: %s got: %v", i, tc.msg, err)
+			}
+			continue
+		}
+		if err != nil || n != tc.exp {
+			t.Errorf("tc[%d] mismatch expected: %#v got: %#v (%v)", i, tc.exp, n, err)
+		}
+	}
+
+	os.Setenv(key, "^1.2 || 3")
+	if rs, err := semver.RangesFromEnv(key, nil); err != nil || rs.String() != "1.2.0 - 1.255.255 || 3.0.0 - 3.255.255" {
+		t.Errorf("ranges mismatch expected: %s got: %s (%v)", "1.2.0 - 1.255.255 || 3.0.0 - 3.255.255", rs, err)
+	}
+	os.Setenv(key, "^1.2 ||")
+	var empty semver.ErrorEmpty
+	if _, err := semver.RangesFromEnv(key, nil); !errors.As(err, &empty) {
+		t.Errorf("ranges error mismatch expected: %v got: %v", semver.ErrorEmpty{}, err)
+	}
+}
+
+func ExampleNumberVar() {
+	var (
+		fs         = flag.NewFlagSet("app", flag.ExitOnError)
+		minVersion semver.Number
+	)
+	semver.NumberVar(fs, &minVersion, "min-version", semver.NewNumber(1, 0, 0), "minimum supported version")
+	fs.Parse([]string{"--min-version=1.2"})
+	fmt.Println(minVersion.GoString())
+	// Output: 1.2.0
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package gittag discovers the versions tagged in a local git repository
+// and computes the next version to release.
+//
+// Tags are read straight from the loose references under refs/tags and
+// from the packed-refs file, so the git command isn't needed. Only tags
+// starting with the configured prefix, such as "v" or "myapp/v", whose
+// remainder is made of one to three numeric components, e.g. "1.2" or
+// "1.2.3", are taken into account; any other tag is ignored.
+package gittag
+
+import (
+	"bufio"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// ErrNotRepository is the error produced when no git repository is found.
+var ErrNotRepository = errors.New("gittag: not a git repository")
+
+const tagsPrefix = "refs/tags/"
+
+// Repo is a local git repository.
+type Repo struct {
+	// Prefix precedes the version in the name of the tags, e.g. "v".
+	Prefix string
+
+	gitDir string
+}
+
+// Open returns the Repo holding dir, looking for it in dir and its parents.
+// dir may also be the git directory of a bare repository.
+func Open(dir string) (*Repo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for d := dir; ; d = filepath.Dir(d) {
+		if gitDir, ok := findGitDir(d); ok {
+			return &Repo{gitDir: gitDir}, nil
+		}
+		if filepath.Dir(d) == d {
+			return nil, ErrNotRepository
+		}
+	}
+}
+
+// findGitDir returns the git directory of the repository rooted at dir.
+func findGitDir(dir string) (string, bool) {
+	dotGit := filepath.Join(dir, ".git")
+	fi, err := os.Stat(dotGit)
+	switch {
+	case err == nil && fi.IsDir():
+		return commonDir(dotGit), true
+	case err == nil:
+		// linked worktrees and submodules: "gitdir: <path>"
+		b, err := ioutil.ReadFile(dotGit)
+		if err != nil || !strings.HasPrefix(string(b), "gitdir:") {
+			return "", false
+		}
+		gitDir := strings.TrimSpace(strings.TrimPrefix(string(b), "gitdir:"))
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(dir, gitDir)
+		}
+		return commonDir(gitDir), true
+	}
+
+	// bare repositories
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil {
+		return "", false
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "refs")); err != nil || !fi.IsDir() {
+		return "", false
+	}
+	return commonDir(dir), true
+}
+
+// commonDir returns the directory holding the references shared by
+// every worktree of the repository whose git directory is gitDir.
+func commonDir(gitDir string) string {
+	b, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	dir := strings.TrimSpace(string(b))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(gitDir, dir)
+	}
+	return dir
+}
+
+// Tags returns the sorted names of every tag in the repository.
+func (r *Repo) Tags() ([]string, error) {
+	set := map[string]struct{}{}
+
+	root := filepath.Join(r.gitDir, filepath.FromSlash(tagsPrefix))
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return nil
+			}
+			return err
+		}
+		if !fi.IsDir() {
+			name, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			set[filepath.ToSlash(name)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(r.gitDir, "packed-refs"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	} else if err == nil {
+		defer f.Close()
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			// "<hash> refs/tags/<name>", skipping the "# pack-refs" header
+			// and the "^<hash>" lines peeling annotated tags.
+			fields := strings.Fields(s.Text())
+			if len(fields) == 2 && strings.HasPrefix(fields[1], tagsPrefix) {
+				set[strings.TrimPrefix(fields[1], tagsPrefix)] = struct{}{}
+			}
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	tags := make([]string, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// Numbers returns, in ascending order, the versions tagged in the repository.
+func (r *Repo) Numbers() (semver.Numbers, error) {
+	tags, err := r.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[semver.Number]bool{}
+	var ns semver.Numbers
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, r.Prefix) || !numeric(strings.TrimPrefix(tag, r.Prefix)) {
+			continue
+		}
+		n, err := semver.ParseNumber(strings.TrimPrefix(tag, r.Prefix))
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		ns = append(ns, n)
+	}
+	sort.Sort(ns)
+	return ns, nil
+}
+
+// numeric tells whether v is made of one to three non-empty
+// numeric components, which semver.ParseNumber alone doesn't enforce.
+func numeric(v string) bool {
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" {
+			return false
+		}
+		for i := 0; i < len(p); i++ {
+			if p[i] < '0' || p[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Latest returns the newest version tagged in the repository,
+// false is returned when there is none.
+func (r *Repo) Latest() (semver.Number, bool, error) {
+	ns, err := r.Numbers()
+	if err != nil || len(ns) == 0 {
+		return 0, false, err
+	}
+	return ns[len(ns)-1], true, nil
+}
+
+// LatestPerLine returns the newest version tagged in every release line,
+// as described by semver.Numbers.LatestPerLine.
+func (r *Repo) LatestPerLine(p semver.Part) (semver.Numbers, error) {
+	ns, err := r.Numbers()
+	if err != nil {
+		return nil, err
+	}
+	return ns.LatestPerLine(p), nil
+}
+
+// Next returns the version following the newest one tagged in the
+// repository once the specified component is bumped. When nothing has been
+// tagged yet it bumps 0.0.0.
+func (r *Repo) Next(p semver.Part) (semver.Number, error) {
+	n, _, err := r.Latest()
+	if err != nil {
+		return 0, err
+	}
+	return n.Bump(p)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package gittag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/gittag"
+)
+
+// repo creates a throwaway repository with a commit tagged with tags,
+// annotating the odd ones, and packing the references when packed is set.
+func repo(t *testing.T, packed bool, tags ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir, err := ioutil.TempDir("", "gittag")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_CONFIG_NOSYSTEM=1", "HOME="+dir,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "initial")
+	for i, tag := range tags {
+		if i%2 == 1 {
+			git("tag", "-a", "-m", tag, tag)
+		} else {
+			git("tag", tag)
+		}
+	}
+	if packed {
+		git("pack-refs", "--all")
+	}
+	return dir
+}
+
+func TestRepo(t *testing.T) {
+	for _, packed := range []bool{false, true} {
+		dir := repo(t, packed, "v1.0.0", "v1.1.0", "v1.1.3", "v2.0.0", "v2.1.0-rc1", "1.5.0", "latest", "myapp/v0.3.1", "myapp/v0.4", "v3.0.0.0", "v.3")
+		defer os.RemoveAll(dir)
+
+		sub := filepath.Join(dir, "cmd", "app")
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			t.Fatal(err)
+		}
+		r, err := gittag.Open(sub)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags, err := r.Tags()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tags) != 11 {
+			t.Errorf("packed: %t tag count mismatch expected: 11 got: %d (%v)", packed, len(tags), tags)
+		}
+
+		var tcs = []struct {
+			prefix string
+			all    semver.Numbers
+			lines  semver.Numbers
+			next   semver.Number
+		}{
+			{
+				"v",
+				semver.Numbers{semver.NewNumber(1, 0, 0), semver.NewNumber(1, 1, 0), semver.NewNumber(1, 1, 3), semver.NewNumber(2, 0, 0)},
+				semver.Numbers{semver.NewNumber(1, 0, 0), semver.NewNumber(1, 1, 3), semver.NewNumber(2, 0, 0)},
+				semver.NewNumber(2, 1, 0),
+			},
+			{
+				"myapp/v",
+				semver.Numbers{semver.NewNumber(0, 3, 1), semver.NewNumber(0, 4, 0)},
+				semver.Numbers{semver.NewNumber(0, 3, 1), semver.NewNumber(0, 4, 0)},
+				semver.NewNumber(0, 5, 0),
+			},
+			{"other/v", nil, nil, semver.NewNumber(0, 1, 0)},
+		}
+
+		for i, tc := range tcs {
+			r.Prefix = tc.prefix
+			ns, err := r.Numbers()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ns, tc.all) {
+				t.Errorf("tc[%d] packed: %t numbers mismatch expected: %v got: %v", i, packed, tc.all, ns)
+			}
+			if ns, _ = r.LatestPerLine(semver.PartMinor); !reflect.DeepEqual(ns, tc.lines) {
+				t.Errorf("tc[%d] packed: %t lines mismatch expected: %v got: %v", i, packed, tc.lines, ns)
+			}
+			if n, _ := r.Next(semver.PartMinor); n != tc.next {
+				t.Errorf("tc[%d] packed: %t next mismatch expected: %s got: %s", i, packed, tc.next.GoString(), n.GoString())
+			}
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gittag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := gittag.Open(dir); !errors.Is(err, gittag.ErrNotRepository) {
+		t.Errorf("error mismatch expected: %v got: %v", gittag.ErrNotRepository, err)
+	}
+}
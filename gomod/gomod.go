@@ -0,0 +1,229 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package gomod converts between semver.Number values and Go module
+// versions, such as "v1.2.3", "v2.0.0+incompatible" or the pseudo-version
+// "v0.0.0-20200102150405-abcdef123456", and reads the versions required
+// by go.mod files.
+//
+// A Number holds no prerelease nor build metadata, so converting module
+// versions carrying any of them, including pseudo-versions and the
+// +incompatible suffix, is lossy; Version reports what was dropped.
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// ErrInvalid is the error produced for malformed module versions.
+var ErrInvalid = errors.New("gomod: invalid module version")
+
+// SyntaxError is the error produced when reading a malformed go.mod file.
+type SyntaxError struct {
+	Line int
+	Err  error
+}
+
+// Unwrap returns the error SyntaxError is wrapping.
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string { return fmt.Sprintf("go.mod:%d: %s", e.Line, e.Err.Error()) }
+
+var errRequire = errors.New("malformed require: expected a module path and a version")
+
+// Version is a Go module version.
+type Version struct {
+	Number semver.Number
+	// Prerelease and Build are the prerelease and build metadata
+	// identifiers, without their leading '-' and '+'.
+	Prerelease, Build string
+	// Pseudo is set for pseudo-versions, which refer to a commit.
+	Pseudo bool
+	// Incompatible is set for the +incompatible major versions
+	// of modules without a go.mod file.
+	Incompatible bool
+}
+
+// Lossy tells whether converting the Version to its Number dropped anything.
+func (v Version) Lossy() bool {
+	return v.Prerelease != "" || v.Build != "" || v.Incompatible
+}
+
+// String satisfies the fmt.Stringer interface.
+func (v Version) String() string {
+	s := Format(v.Number)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	if v.Incompatible {
+		s += "+incompatible"
+	}
+	return s
+}
+
+// Format returns the canonical module version of the Number, e.g. "v1.2.0".
+func Format(n semver.Number) string { return "v" + n.GoString() }
+
+// Parse parses the module version s.
+func Parse(s string) (Version, error) { return parse(s, true) }
+
+// ParseLenient parses s like Parse does, but without requiring either
+// the 'v' prefix nor the three numeric components of module versions,
+// e.g. "1.2" or "v1.2-rc.1"; as versions set with -ldflags often are.
+func ParseLenient(s string) (Version, error) { return parse(s, false) }
+
+func parse(s string, strict bool) (Version, error) {
+	if strict && !strings.HasPrefix(s, "v") {
+		return Version{}, fmt.Errorf("%w %q: missing 'v' prefix", ErrInvalid, s)
+	}
+
+	var v Version
+	core := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(core, '+'); i >= 0 {
+		core, v.Build = core[:i], core[i+1:]
+		if v.Build == "incompatible" {
+			v.Build, v.Incompatible = "", true
+		}
+		if !identifiers(v.Build, v.Incompatible) {
+			return Version{}, fmt.Errorf("%w %q: malformed build metadata", ErrInvalid, s)
+		}
+	}
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		core, v.Prerelease = core[:i], core[i+1:]
+		if !identifiers(v.Prerelease, false) {
+			return Version{}, fmt.Errorf("%w %q: malformed prerelease", ErrInvalid, s)
+		}
+		v.Pseudo = isPseudo(v.Prerelease)
+	}
+
+	if strict && !canonical(core) {
+		return Version{}, fmt.Errorf("%w %q: expected vMAJOR.MINOR.PATCH", ErrInvalid, s)
+	}
+	n, err := semver.ParseNumber(core)
+	if err != nil {
+		return Version{}, err
+	}
+	if v.Incompatible && n.Major() < 2 {
+		return Version{}, fmt.Errorf("%w %q: +incompatible requires major version 2 or above", ErrInvalid, s)
+	}
+	v.Number = n
+	return v, nil
+}
+
+// canonical tells whether core is made of exactly three
+// numeric components with no leading zeros, e.g. "1.2.3".
+func canonical(core string) bool {
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" || len(p) > 1 && p[0] == '0' {
+			return false
+		}
+		for i := 0; i < len(p); i++ {
+			if p[i] < '0' || p[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// identifiers tells whether s is a dot separated list of
+// alphanumeric identifiers, which may be empty if allowEmpty is set.
+func identifiers(s string, allowEmpty bool) bool {
+	if s == "" {
+		return allowEmpty
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isPseudo tells whether the prerelease ends like that of a pseudo-version:
+// a 14 digit timestamp and a revision, e.g. "20200102150405-abcdef123456".
+func isPseudo(pre string) bool {
+	i := strings.LastIndexByte(pre, '-')
+	if i < 14 || i == len(pre)-1 {
+		return false
+	}
+	stamp := pre[i-14 : i]
+	if i > 14 && pre[i-15] != '.' {
+		return false
+	}
+	for _, r := range stamp {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Requires returns the versions of the modules required by
+// the go.mod file data, keyed by module path. Their Lossy method
+// tells which ones do not convert to a Number without losing anything.
+func Requires(data []byte) (map[string]Version, error) {
+	reqs := map[string]Version{}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	var block bool
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if i := strings.Index(text, "//"); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case block && fields[0] == ")":
+			block = false
+			continue
+		case block:
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			block = true
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, &SyntaxError{line, errRequire}
+		}
+		path := fields[0]
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+		v, err := Parse(fields[1])
+		if err != nil {
+			return nil, &SyntaxError{line, err}
+		}
+		reqs[path] = v
+	}
+	return reqs, s.Err()
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package gomod_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/gomod"
+)
+
+func TestParse(t *testing.T) {
+	var tcs = []struct {
+		s     string
+		exp   gomod.Version
+		lossy bool
+		err   bool
+	}{
+		{"v1.2.3", gomod.Version{Number: semver.NewNumber(1, 2, 3)}, false, false},
+		{"v1.2.3-rc.1", gomod.Version{Number: semver.NewNumber(1, 2, 3), Prerelease: "rc.1"}, true, false},
+		{"v1.2.3+meta", gomod.Version{Number: semver.NewNumber(1, 2, 3), Build: "meta"}, true, false},
+		{"v2.0.1+incompatible", gomod.Version{Number: semver.NewNumber(2, 0, 1), Incompatible: true}, true, false},
+		{
+			"v0.0.0-20200102150405-abcdef123456",
+			gomod.Version{Number: semver.NewNumber(0, 0, 0), Prerelease: "20200102150405-abcdef123456", Pseudo: true}, true, false,
+		},
+		{
+			"v1.2.4-0.20200102150405-abcdef123456",
+			gomod.Version{Number: semver.NewNumber(1, 2, 4), Prerelease: "0.20200102150405-abcdef123456", Pseudo: true}, true, false,
+		},
+		{
+			"v1.2.3-pre.0.20200102150405-abcdef123456+incompatible",
+			gomod.Version{Number: semver.NewNumber(1, 2, 3), Prerelease: "pre.0.20200102150405-abcdef123456", Pseudo: true, Incompatible: true}, true, true,
+		},
+		{"v1.2.3-x20200102150405-abcdef123456", gomod.Version{Number: semver.NewNumber(1, 2, 3), Prerelease: "x20200102150405-abcdef123456"}, true, false},
+		{"1.2.3", gomod.Version{}, false, true},
+		{"v1.2", gomod.Version{}, false, true},
+		{"v1.2.3.4", gomod.Version{}, false, true},
+		{"v1..3", gomod.Version{}, false, true},
+		{"v01.2.3", gomod.Version{}, false, true},
+		{"v1.2.03", gomod.Version{}, false, true},
+		{"v1.2.3-", gomod.Version{}, false, true},
+		{"v1.2.3-rc..1", gomod.Version{}, false, true},
+		{"v1.2.3+", gomod.Version{}, false, true},
+		{"v1.2.3+incompatible", gomod.Version{}, false, true},
+		{"v1.256.0", gomod.Version{}, false, true},
+	}
+
+	for i, tc := range tcs {
+		v, err := gomod.Parse(tc.s)
+		if (err != nil) != tc.err || err != nil && tc.s != "v1.256.0" && !errors.Is(err, gomod.ErrInvalid) {
+			t.Errorf("tc[%d] %s error mismatch expected: %t got: %v", i, tc.s, tc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if v != tc.exp {
+			t.Errorf("tc[%d] mismatch expected: %+v got: %+v", i, tc.exp, v)
+		}
+		if v.Lossy() != tc.lossy {
+			t.Errorf("tc[%d] %s lossy mismatch expected: %t got: %t", i, tc.s, tc.lossy, v.Lossy())
+		}
+		if v.String() != tc.s {
+			t.Errorf("tc[%d] string mismatch expected: %s got: %s", i, tc.s, v.String())
+		}
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	var tcs = []struct {
+		s   string
+		exp gomod.Version
+		err bool
+	}{
+		{"v1.2.3", gomod.Version{Number: semver.NewNumber(1, 2, 3)}, false},
+		{"1.2", gomod.Version{Number: semver.NewNumber(1, 2, 0)}, false},
+		{"v2-rc.1", gomod.Version{Number: semver.NewNumber(2, 0, 0), Prerelease: "rc.1"}, false},
+		{"1.2.x", gomod.Version{}, true},
+		{"1.2.3-", gomod.Version{}, true},
+		{"", gomod.Version{}, true},
+	}
+
+	for i, tc := range tcs {
+		v, err := gomod.ParseLenient(tc.s)
+		if (err != nil) != tc.err {
+			t.Errorf("tc[%d] %s error mismatch expected: %t got: %v", i, tc.s, tc.err, err)
+			continue
+		}
+		if v != tc.exp {
+			t.Errorf("tc[%d] mismatch expected: %+v got: %+v", i, tc.exp, v)
+		}
+	}
+}
+
+func TestRequires(t *testing.T) {
+	data := []byte(`module example.com/app // the app
+
+go 1.15
+
+require example.com/single v1.2.3
+
+require (
+	// commented out
+	example.com/a v0.4.1 // indirect
+	"example.com/quoted" v2.0.0+incompatible
+	example.com/pseudo v0.0.0-20200102150405-abcdef123456
+)
+
+replace example.com/a => ../a
+`)
+
+	exp := map[string]gomod.Version{
+		"example.com/single": {Number: semver.NewNumber(1, 2, 3)},
+		"example.com/a":      {Number: semver.NewNumber(0, 4, 1)},
+		"example.com/quoted": {Number: semver.NewNumber(2, 0, 0), Incompatible: true},
+		"example.com/pseudo": {Number: semver.NewNumber(0, 0, 0), Prerelease: "20200102150405-abcdef123456", Pseudo: true},
+	}
+	reqs, err := gomod.Requires(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reqs, exp) {
+		t.Errorf("mismatch expected: %v got: %v", exp, reqs)
+	}
+	if reqs["example.com/single"].Lossy() || !reqs["example.com/pseudo"].Lossy() {
+		t.Errorf("lossy mismatch expected: false true got: %t %t", reqs["example.com/single"].Lossy(), reqs["example.com/pseudo"].Lossy())
+	}
+
+	var se *gomod.SyntaxError
+	_, err = gomod.Requires([]byte("module m\n\nrequire (\n\texample.com/a 1.2.3\n)\n"))
+	if !errors.As(err, &se) || se.Line != 4 || !errors.Is(err, gomod.ErrInvalid) {
+		t.Errorf("error mismatch expected: line 4 %v got: %v", gomod.ErrInvalid, err)
+	}
+	_, err = gomod.Requires([]byte("require example.com/a\n"))
+	if !errors.As(err, &se) || se.Line != 1 {
+		t.Errorf("error mismatch expected: line 1 got: %v", err)
+	}
+}
+
+func ExampleParse() {
+	v, _ := gomod.Parse("v1.5.0-rc.2")
+	fmt.Println(v.Number, v.Prerelease, v.Lossy())
+	fmt.Println(gomod.Format(v.Number))
+	// Output:
+	// 1.5 rc.2 true
+	// v1.5.0
+}
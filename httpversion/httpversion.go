@@ -0,0 +1,177 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package httpversion routes HTTP requests to the handler registered for
+// the greatest API version satisfying the one requested by the client.
+//
+// Clients request versions as constraints understood by
+// semver.ParseRanges, e.g.: "Accept-Version: ^2.1", "?version=2" or a
+// "/v2.3/" path prefix. The chosen Number is stored in the request context,
+// where semver.FromContext finds it, and reported in a response header.
+package httpversion
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Router is an http.Handler that dispatches each request to the handler
+// registered for the greatest version satisfying the requested one, or to
+// the greatest version overall if none is requested.
+//
+// Requests for versions that no handler satisfies get a
+// 406 Not Acceptable response listing the supported versions.
+type Router struct {
+	// Header is the request header holding the requested version;
+	// it is ignored when empty.
+	Header string
+	// Query is the query parameter holding the requested version;
+	// it is ignored when empty.
+	Query string
+	// Path enables requesting versions by means of a path prefix
+	// such as "/v2.3/", which is stripped before calling the handler.
+	Path bool
+	// ResponseHeader is the response header reporting the chosen version;
+	// it is not set when empty.
+	ResponseHeader string
+
+	versions semver.Numbers
+	handlers map[semver.Number]http.Handler
+}
+
+// NewRouter creates a Router honoring the "Accept-Version" header,
+// the "version" query parameter and path prefixes;
+// and reporting the chosen version in the "Content-Version" header.
+func NewRouter() *Router {
+	return &Router{
+		Header:         "Accept-Version",
+		Query:          "version",
+		Path:           true,
+		ResponseHeader: "Content-Version",
+	}
+}
+
+// Handle registers the handler for the specified version,
+// replacing the previous one, if any.
+func (rt *Router) Handle(v semver.Number, h http.Handler) {
+	if rt.handlers == nil {
+		rt.handlers = make(map[semver.Number]http.Handler)
+	}
+	if _, ok := rt.handlers[v]; !ok {
+		rt.versions = append(rt.versions, v)
+		sort.Sort(rt.versions)
+	}
+	rt.handlers[v] = h
+}
+
+// HandleFunc registers the handler function for the specified version.
+func (rt *Router) HandleFunc(v semver.Number, f func(http.ResponseWriter, *http.Request)) {
+	rt.Handle(v, http.HandlerFunc(f))
+}
+
+// Versions returns the registered versions in ascending order.
+func (rt *Router) Versions() semver.Numbers {
+	return append(semver.Numbers(nil), rt.versions...)
+}
+
+// ServeHTTP satisfies the http.Handler interface.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requested, path, ok := rt.requested(r)
+
+	constraint := semver.Ranges{{Min: 0, Max: semver.MaxNumber}}
+	if requested != "" {
+		rs, err := semver.ParseRanges(requested)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		constraint = rs
+	}
+
+	v, found := rt.best(constraint)
+	if !found {
+		var b strings.Builder
+		b.WriteString("no version satisfies \"")
+		b.WriteString(requested)
+		b.WriteString("\"; supported versions: ")
+		for i, n := range rt.versions {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(n.GoString())
+		}
+		http.Error(w, b.String(), http.StatusNotAcceptable)
+		return
+	}
+
+	r = r.WithContext(semver.WithNumber(r.Context(), v))
+	if ok {
+		u := *r.URL
+		u.Path, u.RawPath = path, ""
+		r.URL = &u
+	}
+	if rt.ResponseHeader != "" {
+		w.Header().Set(rt.ResponseHeader, v.String())
+	}
+	rt.handlers[v].ServeHTTP(w, r)
+}
+
+// requested returns the version requested by r, if any, looking at its
+// path, its header and its query, in that order. When the version is found
+// in the path, it also returns the path without the version prefix.
+func (rt *Router) requested(r *http.Request) (string, string, bool) {
+	if rt.Path {
+		if v, path, ok := versionPrefix(r.URL.Path); ok {
+			return v, path, true
+		}
+	}
+	if rt.Header != "" {
+		if v := r.Header.Get(rt.Header); v != "" {
+			return v, "", false
+		}
+	}
+	if rt.Query != "" {
+		if v := r.URL.Query().Get(rt.Query); v != "" {
+			return v, "", false
+		}
+	}
+	return "", "", false
+}
+
+// best returns the greatest registered version within rs.
+func (rt *Router) best(rs semver.Ranges) (semver.Number, bool) {
+	for i := len(rt.versions) - 1; i >= 0; i-- {
+		if rs.Contains(rt.versions[i]) {
+			return rt.versions[i], true
+		}
+	}
+	return 0, false
+}
+
+// versionPrefix splits paths such as "/v2.3/users" into "2.3" and "/users".
+func versionPrefix(path string) (string, string, bool) {
+	if len(path) < 3 || path[0] != '/' || path[1] != 'v' {
+		return "", "", false
+	}
+
+	end := strings.IndexByte(path[1:], '/') + 1
+	if end == 0 {
+		end = len(path)
+	}
+
+	v := path[2:end]
+	if v == "" || strings.Trim(v, "0123456789.xX*") != "" {
+		return "", "", false
+	}
+
+	rest := path[end:]
+	if rest == "" {
+		rest = "/"
+	}
+	return v, rest, true
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package httpversion_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/httpversion"
+)
+
+func newRouter() *httpversion.Router {
+	rt := httpversion.NewRouter()
+	for _, v := range []semver.Number{
+		semver.NewNumber(1, 0, 0),
+		semver.NewNumber(2, 3, 0),
+		semver.NewNumber(2, 1, 0),
+		semver.NewNumber(3, 0, 0),
+	} {
+		rt.HandleFunc(v, func(w http.ResponseWriter, r *http.Request) {
+			n, _ := semver.FromContext(r.Context())
+			fmt.Fprintf(w, "%s %s", n, r.URL.Path)
+		})
+	}
+	return rt
+}
+
+func TestRouter(t *testing.T) {
+	var tcs = []struct {
+		target, header string
+		status         int
+		version, body  string
+	}{
+		{"/users", "", http.StatusOK, "3", "3 /users"},
+		{"/users", "^2.1", http.StatusOK, "2.3", "2.3 /users"},
+		{"/users", "~2.1", http.StatusOK, "2.1", "2.1 /users"},
+		{"/users", "1", http.StatusOK, "1", "1 /users"},
+		{"/users?version=2", "", http.StatusOK, "2.3", "2.3 /users"},
+		{"/users?version=2", "1.x", http.StatusOK, "1", "1 /users"},
+		{"/v2.1/users", "^3", http.StatusOK, "2.1", "2.1 /users"},
+		{"/v2", "", http.StatusOK, "2.3", "2.3 /"},
+		{"/v2.x/users/v1", "", http.StatusOK, "2.3", "2.3 /users/v1"},
+		{"/version/users", "", http.StatusOK, "3", "3 /version/users"},
+		{"/users", "^4", http.StatusNotAcceptable, "", "no version satisfies \"^4\"; supported versions: 1.0.0, 2.1.0, 2.3.0, 3.0.0\n"},
+		{"/v2.2/users", "", http.StatusNotAcceptable, "", "no version satisfies \"2.2\"; supported versions: 1.0.0, 2.1.0, 2.3.0, 3.0.0\n"},
+		{"/users", "^a", http.StatusBadRequest, "", "semver: invalid character 'a' in: \"a\"\n"},
+	}
+
+	rt := newRouter()
+	for i, tc := range tcs {
+		r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		if tc.header != "" {
+			r.Header.Set("Accept-Version", tc.header)
+		}
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, r)
+
+		res := w.Result()
+		body, _ := ioutil.ReadAll(res.Body)
+		if res.StatusCode != tc.status {
+			t.Errorf("tc[%d] status mismatch expected: %d got: %d", i, tc.status, res.StatusCode)
+		}
+		if got := res.Header.Get("Content-Version"); got != tc.version {
+			t.Errorf("tc[%d] version header mismatch expected: %s got: %s", i, tc.version, got)
+		}
+		if got := string(body); got != tc.body {
+			t.Errorf("tc[%d] body mismatch expected: %q got: %q", i, tc.body, got)
+		}
+	}
+}
+
+func TestRouterConfiguration(t *testing.T) {
+	rt := newRouter()
+	rt.Header, rt.Query, rt.Path, rt.ResponseHeader = "X-API-Version", "", false, ""
+
+	var tcs = []struct {
+		target, header, body string
+	}{
+		{"/v1/users?version=1", "", "3 /v1/users"},
+		{"/v1/users", "2.1", "2.1 /v1/users"},
+	}
+
+	for i, tc := range tcs {
+		r := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		r.Header.Set("X-API-Version", tc.header)
+		w := httptest.NewRecorder()
+		rt.ServeHTTP(w, r)
+
+		if got := w.Body.String(); got != tc.body {
+			t.Errorf("tc[%d] body mismatch expected: %q got: %q", i, tc.body, got)
+		}
+		if got := w.Header().Get("Content-Version"); got != "" {
+			t.Errorf("tc[%d] no version header expected got: %s", i, got)
+		}
+	}
+
+	if exp, got := "[1 2.1 2.3 3]", fmt.Sprint(rt.Versions()); exp != got {
+		t.Errorf("versions mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func ExampleRouter() {
+	rt := httpversion.NewRouter()
+	rt.HandleFunc(semver.NewNumber(1, 0, 0), func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "v1")
+	})
+	rt.HandleFunc(semver.NewNumber(2, 1, 0), func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "v2")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("Accept-Version", "^2")
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, r)
+
+	fmt.Println(w.Body.String(), w.Header().Get("Content-Version"))
+	// Output: v2 2.1
+}
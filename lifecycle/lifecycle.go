@@ -0,0 +1,255 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package lifecycle computes whether a semver.Number is supported,
+// deprecated or has reached its end of life on a given date, according
+// to the release history of a project and a declarative support Policy.
+//
+// Support is granted per minor line: e.g., 1.2.0 and 1.2.7 belong to the
+// 1.2 line. When a line falls out of support, because of a newer release
+// or because its support window since its first release elapsed, it becomes
+// deprecated for a grace period and reaches its end of life afterwards.
+package lifecycle
+
+import (
+	"sort"
+	"time"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Release is a Number published on a specific date.
+type Release struct {
+	Number semver.Number `json:"version" yaml:"version"`
+	Date   time.Time     `json:"date" yaml:"date"`
+}
+
+// Policy declares which minor lines are supported.
+//
+// E.g.: "the latest two minors of each of the last two majors, 12 months
+// after release" is Policy{Majors: 2, Minors: 2, SupportMonths: 12}.
+type Policy struct {
+	// Majors is how many of the latest majors are supported;
+	// 0 means all of them.
+	Majors int `json:"majors" yaml:"majors"`
+	// Minors is how many of the latest minor lines of each supported major
+	// are supported; 0 means all of them.
+	Minors int `json:"minors" yaml:"minors"`
+	// SupportMonths is how many months a line is supported at most,
+	// counted from its first release; 0 means no limit.
+	SupportMonths int `json:"supportMonths" yaml:"supportMonths"`
+	// GraceMonths is how many months a line remains deprecated,
+	// after falling out of support, before it reaches its end of life.
+	GraceMonths int `json:"graceMonths" yaml:"graceMonths"`
+}
+
+// Status is the support status of a Number.
+type Status byte
+
+const (
+	// Unreleased means the Number does not belong to any released line.
+	Unreleased Status = iota
+	// Supported means the Number belongs to a supported line.
+	Supported
+	// Deprecated means the Number belongs to a line that
+	// is no longer supported but has not reached its end of life.
+	Deprecated
+	// EOL means the Number belongs to a line that reached its end of life.
+	EOL
+)
+
+// String satisfies the fmt.Stringer interface.
+func (s Status) String() string {
+	switch s {
+	case Unreleased:
+		return "unreleased"
+	case Supported:
+		return "supported"
+	case Deprecated:
+		return "deprecated"
+	case EOL:
+		return "eol"
+	}
+	return "unknown"
+}
+
+// Verdict is the support status of a Number on a given date.
+type Verdict struct {
+	Status Status
+	// EndOfLife is when the line of the Number reaches (or reached) its
+	// end of life; it is zero while the line is supported or unreleased.
+	EndOfLife time.Time
+	// Upgrade is the Number to upgrade to: the latest release of the line,
+	// if supported; or else the latest supported release of the same major
+	// or, if none, the latest supported release; zero if there is none.
+	Upgrade semver.Number
+}
+
+// Schedule holds the release history of a project and its support Policy.
+type Schedule struct {
+	Policy   Policy    `json:"policy" yaml:"policy"`
+	Releases []Release `json:"releases" yaml:"releases"`
+}
+
+// Status returns the Verdict for the specified Number on the specified
+// date. Only releases published on or before that date are considered.
+func (s Schedule) Status(n semver.Number, at time.Time) Verdict {
+	var rs []Release
+	for _, r := range s.Releases {
+		if !r.Date.After(at) {
+			rs = append(rs, r)
+		}
+	}
+	sort.SliceStable(rs, func(i, j int) bool { return rs[i].Date.Before(rs[j].Date) })
+
+	line := n.Truncate(semver.PartMinor)
+	released, wasSupported := false, false
+	var dropped time.Time
+	for i, r := range rs {
+		if r.Number.Truncate(semver.PartMinor) == line {
+			released = true
+		}
+		if !released {
+			continue
+		}
+		// releases sharing the same date are evaluated together.
+		if i+1 < len(rs) && rs[i+1].Date.Equal(r.Date) {
+			continue
+		}
+		supported := s.Policy.supported(rs[:i+1])[line]
+		if supported {
+			dropped = time.Time{}
+		} else if wasSupported || dropped.IsZero() {
+			dropped = r.Date
+		}
+		wasSupported = supported
+	}
+	if expiry, ok := s.Policy.expiry(rs, line); ok && !at.Before(expiry) {
+		if wasSupported || expiry.Before(dropped) {
+			dropped = expiry
+		}
+		wasSupported = false
+	}
+
+	if !released {
+		return Verdict{Status: Unreleased, Upgrade: s.Policy.upgrade(rs, n, at, false)}
+	}
+	if wasSupported {
+		return Verdict{Status: Supported, Upgrade: s.Policy.upgrade(rs, n, at, true)}
+	}
+
+	v := Verdict{
+		Status:    Deprecated,
+		EndOfLife: dropped.AddDate(0, s.Policy.GraceMonths, 0),
+		Upgrade:   s.Policy.upgrade(rs, n, at, false),
+	}
+	if !at.Before(v.EndOfLife) {
+		v.Status = EOL
+	}
+	return v
+}
+
+// Supported returns the lines supported on the specified date,
+// as Numbers truncated to their minor component, in ascending order.
+func (s Schedule) Supported(at time.Time) semver.Numbers {
+	var rs []Release
+	for _, r := range s.Releases {
+		if !r.Date.After(at) {
+			rs = append(rs, r)
+		}
+	}
+
+	var lines semver.Numbers
+	for line := range s.Policy.current(rs, at) {
+		lines = append(lines, line)
+	}
+	sort.Sort(lines)
+	return lines
+}
+
+// supported returns the lines supported once the specified releases are out.
+func (p Policy) supported(rs []Release) map[semver.Number]bool {
+	ns := make(semver.Numbers, len(rs))
+	for i, r := range rs {
+		ns[i] = r.Number
+	}
+
+	majors := ns.Lines(semver.PartMajor)
+	if p.Majors > 0 && len(majors) > p.Majors {
+		majors = majors[len(majors)-p.Majors:]
+	}
+
+	lines := ns.GroupBy(semver.PartMajor)
+	supported := make(map[semver.Number]bool)
+	for _, major := range majors {
+		minors := lines[major].Lines(semver.PartMinor)
+		if p.Minors > 0 && len(minors) > p.Minors {
+			minors = minors[len(minors)-p.Minors:]
+		}
+		for _, line := range minors {
+			supported[line] = true
+		}
+	}
+	return supported
+}
+
+// expiry returns when the support window of the line ends, if the
+// Policy limits it and the line was released among the specified releases.
+func (p Policy) expiry(rs []Release, line semver.Number) (time.Time, bool) {
+	if p.SupportMonths <= 0 {
+		return time.Time{}, false
+	}
+	var first time.Time
+	var found bool
+	for _, r := range rs {
+		if r.Number.Truncate(semver.PartMinor) == line && (!found || r.Date.Before(first)) {
+			first, found = r.Date, true
+		}
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	return first.AddDate(0, p.SupportMonths, 0), true
+}
+
+// current returns the lines supported on the specified date once the
+// specified releases are out, leaving out those whose support window ended.
+func (p Policy) current(rs []Release, at time.Time) map[semver.Number]bool {
+	supported := p.supported(rs)
+	for line := range supported {
+		if expiry, ok := p.expiry(rs, line); ok && !at.Before(expiry) {
+			delete(supported, line)
+		}
+	}
+	return supported
+}
+
+// upgrade returns the Number n should be upgraded to on the specified date.
+func (p Policy) upgrade(rs []Release, n semver.Number, at time.Time, sameLine bool) semver.Number {
+	supported := p.current(rs, at)
+
+	var best, bestMajor semver.Number
+	var found, foundMajor bool
+	for _, r := range rs {
+		line := r.Number.Truncate(semver.PartMinor)
+		switch {
+		case sameLine:
+			if line == n.Truncate(semver.PartMinor) && (!found || r.Number > best) {
+				best, found = r.Number, true
+			}
+		case supported[line]:
+			if !found || r.Number > best {
+				best, found = r.Number, true
+			}
+			if semver.SameMajor(r.Number, n) && (!foundMajor || r.Number > bestMajor) {
+				bestMajor, foundMajor = r.Number, true
+			}
+		}
+	}
+	if foundMajor {
+		return bestMajor
+	}
+	return best
+}
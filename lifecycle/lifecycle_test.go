@@ -0,0 +1,149 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lifecycle_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/lifecycle"
+)
+
+const scheduleYAML = `
+policy:
+  majors: 2
+  minors: 2
+  graceMonths: 12
+releases:
+  - {version: "1.0", date: 2019-01-10T00:00:00Z}
+  - {version: "1.1", date: 2019-04-10T00:00:00Z}
+  - {version: "1.1.1", date: 2019-05-10T00:00:00Z}
+  - {version: "1.2", date: 2019-09-10T00:00:00Z}
+  - {version: "2.0", date: 2020-01-10T00:00:00Z}
+  - {version: "2.1", date: 2020-06-10T00:00:00Z}
+  - {version: "3.0", date: 2021-01-10T00:00:00Z}
+`
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSchedule(t *testing.T) {
+	var s lifecycle.Schedule
+	if err := yaml.Unmarshal([]byte(scheduleYAML), &s); err != nil {
+		t.Fatalf("no yaml unmarshaling error expected got: %s", err.Error())
+	}
+
+	var tcs = []struct {
+		n, at   string
+		status  lifecycle.Status
+		eol     string
+		upgrade string
+	}{
+		{"1.0", "2019-02-01", lifecycle.Supported, "", "1"},
+		{"1.1", "2019-02-01", lifecycle.Unreleased, "", "1"},
+		{"1.1", "2019-06-01", lifecycle.Supported, "", "1.1.1"},
+		{"1.1.0", "2019-06-01", lifecycle.Supported, "", "1.1.1"},
+		{"1.0", "2019-06-01", lifecycle.Supported, "", "1"},
+		{"1.0", "2019-10-01", lifecycle.Deprecated, "2020-09-10", "1.2"},
+		{"1.0", "2020-09-10", lifecycle.EOL, "2020-09-10", "1.2"},
+		{"1.1.1", "2020-12-01", lifecycle.Supported, "", "1.1.1"},
+		{"1.2", "2021-02-01", lifecycle.Deprecated, "2022-01-10", "3"},
+		{"1.1", "2022-02-01", lifecycle.EOL, "2022-01-10", "3"},
+		{"2.0", "2021-02-01", lifecycle.Supported, "", "2"},
+		{"4.0", "2021-02-01", lifecycle.Unreleased, "", "3"},
+	}
+
+	for i, tc := range tcs {
+		n, _ := semver.ParseNumber(tc.n)
+		v := s.Status(n, date(tc.at))
+		if v.Status != tc.status {
+			t.Errorf("tc[%d] status of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.status, v.Status)
+		}
+		if tc.eol == "" && !v.EndOfLife.IsZero() || tc.eol != "" && !v.EndOfLife.Equal(date(tc.eol)) {
+			t.Errorf("tc[%d] end of life of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.eol, v.EndOfLife)
+		}
+		if got := v.Upgrade.String(); got != tc.upgrade {
+			t.Errorf("tc[%d] upgrade of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.upgrade, got)
+		}
+	}
+
+	if exp, got := "[1.1 1.2 2 2.1]", fmt.Sprint(s.Supported(date("2020-07-01"))); exp != got {
+		t.Errorf("supported lines mismatch expected: %s got: %s", exp, got)
+	}
+	if exp, got := "[2 2.1 3]", fmt.Sprint(s.Supported(date("2021-07-01"))); exp != got {
+		t.Errorf("supported lines mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func TestScheduleSupportMonths(t *testing.T) {
+	s := lifecycle.Schedule{
+		Policy: lifecycle.Policy{Majors: 2, Minors: 2, SupportMonths: 12, GraceMonths: 6},
+		Releases: []lifecycle.Release{
+			{Number: semver.NewNumber(1, 0, 0), Date: date("2019-01-10")},
+			{Number: semver.NewNumber(1, 1, 0), Date: date("2019-04-10")},
+			{Number: semver.NewNumber(1, 1, 1), Date: date("2019-05-10")},
+			{Number: semver.NewNumber(2, 0, 0), Date: date("2020-06-10")},
+		},
+	}
+
+	var tcs = []struct {
+		n, at   string
+		status  lifecycle.Status
+		eol     string
+		upgrade string
+	}{
+		{"1.0", "2019-12-01", lifecycle.Supported, "", "1"},
+		{"1.0", "2020-01-10", lifecycle.Deprecated, "2020-07-10", "1.1.1"},
+		{"1.1.1", "2020-03-01", lifecycle.Supported, "", "1.1.1"},
+		{"1.1", "2020-07-01", lifecycle.Deprecated, "2020-10-10", "2"},
+		{"1.0", "2020-07-10", lifecycle.EOL, "2020-07-10", "2"},
+		{"2.0", "2020-07-01", lifecycle.Supported, "", "2"},
+	}
+
+	for i, tc := range tcs {
+		n, _ := semver.ParseNumber(tc.n)
+		v := s.Status(n, date(tc.at))
+		if v.Status != tc.status {
+			t.Errorf("tc[%d] status of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.status, v.Status)
+		}
+		if tc.eol == "" && !v.EndOfLife.IsZero() || tc.eol != "" && !v.EndOfLife.Equal(date(tc.eol)) {
+			t.Errorf("tc[%d] end of life of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.eol, v.EndOfLife)
+		}
+		if got := v.Upgrade.String(); got != tc.upgrade {
+			t.Errorf("tc[%d] upgrade of %s at %s mismatch expected: %s got: %s", i, tc.n, tc.at, tc.upgrade, got)
+		}
+	}
+
+	if exp, got := "[1.1]", fmt.Sprint(s.Supported(date("2020-02-01"))); exp != got {
+		t.Errorf("supported lines mismatch expected: %s got: %s", exp, got)
+	}
+	if exp, got := "[2]", fmt.Sprint(s.Supported(date("2020-07-01"))); exp != got {
+		t.Errorf("supported lines mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func ExampleSchedule_Status() {
+	s := lifecycle.Schedule{
+		Policy: lifecycle.Policy{Majors: 1, Minors: 1, GraceMonths: 6},
+		Releases: []lifecycle.Release{
+			{Number: semver.NewNumber(1, 0, 0), Date: date("2020-01-01")},
+			{Number: semver.NewNumber(1, 1, 0), Date: date("2020-03-01")},
+		},
+	}
+
+	v := s.Status(semver.NewNumber(1, 0, 0), date("2020-05-01"))
+	fmt.Println(v.Status, v.EndOfLife.Format("2006-01-02"), v.Upgrade)
+	// Output: deprecated 2020-09-01 1.1
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile
+
+import (
+	"sort"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Kind classifies a Change.
+type Kind byte
+
+const (
+	// Added means the package is only present in the new Lock.
+	Added Kind = iota + 1
+	// Removed means the package is only present in the old Lock.
+	Removed
+	// Upgraded means the package has a greater version in the new Lock.
+	Upgraded
+	// Downgraded means the package has a lower version in the new Lock.
+	Downgraded
+	// Rehashed means the package kept its version but its hash changed.
+	Rehashed
+)
+
+// String satisfies the fmt.Stringer interface.
+func (k Kind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Upgraded:
+		return "upgraded"
+	case Downgraded:
+		return "downgraded"
+	case Rehashed:
+		return "rehashed"
+	}
+	return "unknown"
+}
+
+// Change describes how a package differs between two Lock values.
+type Change struct {
+	Name     string
+	Kind     Kind
+	Old, New semver.Number
+	// Level is the most significant component that changed
+	// for upgrades and downgrades, and the zero Part otherwise.
+	Level semver.Part
+}
+
+// Diff returns the changes from old to new, sorted by package name.
+// Unchanged packages are not reported.
+func Diff(old, new Lock) []Change {
+	olds := make(map[string]Entry, len(old))
+	for _, e := range old {
+		olds[e.Name] = e
+	}
+
+	var cs []Change
+	for _, n := range new {
+		o, ok := olds[n.Name]
+		delete(olds, n.Name)
+
+		c := Change{Name: n.Name, Old: o.Version, New: n.Version}
+		switch {
+		case !ok:
+			c.Kind = Added
+		case n.Version > o.Version:
+			c.Kind, c.Level = Upgraded, level(o.Version, n.Version)
+		case n.Version < o.Version:
+			c.Kind, c.Level = Downgraded, level(o.Version, n.Version)
+		case n.Hash != o.Hash:
+			c.Kind = Rehashed
+		default:
+			continue
+		}
+		cs = append(cs, c)
+	}
+	for _, o := range olds {
+		cs = append(cs, Change{Name: o.Name, Kind: Removed, Old: o.Version})
+	}
+
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Name < cs[j].Name })
+	return cs
+}
+
+func level(a, b semver.Number) semver.Part {
+	switch {
+	case !semver.SameMajor(a, b):
+		return semver.PartMajor
+	case !semver.SameMinorLine(a, b):
+		return semver.PartMinor
+	}
+	return semver.PartPatch
+}
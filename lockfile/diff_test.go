@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile_test
+
+import (
+	"fmt"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/lockfile"
+)
+
+func TestDiff(t *testing.T) {
+	old := lockfile.Lock{
+		{Name: "auth", Version: semver.NewNumber(1, 1, 0)},
+		{Name: "host", Version: semver.NewNumber(1, 4, 0), Hash: "a"},
+		{Name: "log", Version: semver.NewNumber(1, 3, 0)},
+		{Name: "metrics", Version: semver.NewNumber(2, 0, 0)},
+		{Name: "same", Version: semver.NewNumber(2, 0, 0), Hash: "a"},
+		{Name: "tls", Version: semver.NewNumber(1, 0, 1)},
+		{Name: "zip", Version: semver.NewNumber(1, 0, 0)},
+	}
+	new := lockfile.Lock{
+		{Name: "auth", Version: semver.NewNumber(2, 0, 0)},
+		{Name: "cache", Version: semver.NewNumber(0, 1, 0)},
+		{Name: "host", Version: semver.NewNumber(1, 4, 0), Hash: "b"},
+		{Name: "log", Version: semver.NewNumber(1, 4, 0)},
+		{Name: "metrics", Version: semver.NewNumber(1, 9, 0)},
+		{Name: "same", Version: semver.NewNumber(2, 0, 0), Hash: "a"},
+		{Name: "tls", Version: semver.NewNumber(1, 0, 2)},
+	}
+
+	var exp = []string{
+		"auth upgraded 1.1 -> 2 (major)",
+		"cache added 0 -> 0.1 (none)",
+		"host rehashed 1.4 -> 1.4 (none)",
+		"log upgraded 1.3 -> 1.4 (minor)",
+		"metrics downgraded 2 -> 1.9 (major)",
+		"tls upgraded 1.0.1 -> 1.0.2 (patch)",
+		"zip removed 1 -> 0 (none)",
+	}
+
+	cs := lockfile.Diff(old, new)
+	if len(cs) != len(exp) {
+		t.Fatalf("change count mismatch expected: %d got: %d", len(exp), len(cs))
+	}
+	for i, c := range cs {
+		if got := fmt.Sprintf("%s %s %s -> %s (%s)", c.Name, c.Kind, c.Old, c.New, c.Level); got != exp[i] {
+			t.Errorf("tc[%d] change mismatch expected: %s got: %s", i, exp[i], got)
+		}
+	}
+}
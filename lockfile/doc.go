@@ -0,0 +1,18 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package lockfile persists the exact semver.Number selected for each
+// package of a resolution, so that it can be reproduced later.
+//
+// The text format is deterministic: a header followed by one line per
+// package, sorted by name, holding the name, the version and, optionally,
+// a content hash:
+//
+//	# semver32 lockfile v1
+//	auth 1.1.0 sha256:6b86b273ff34fce1
+//	host 1.4.0
+//
+// A Lock can also be encoded using encoding/json or gopkg.in/yaml.v3.
+package lockfile
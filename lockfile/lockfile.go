@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Header is the first line of every lockfile written by this package.
+const Header = "# semver32 lockfile v1"
+
+// Entry is the version selected for a single package.
+type Entry struct {
+	Name    string        `json:"name" yaml:"name"`
+	Version semver.Number `json:"version" yaml:"version"`
+	Hash    string        `json:"hash,omitempty" yaml:"hash,omitempty"`
+}
+
+// Lock is a set of Entry values, one per package.
+type Lock []Entry
+
+// New creates a Lock out of the version selected for each package,
+// e.g.: a resolve.Solution. Hashes are left empty.
+func New(versions map[string]semver.Number) Lock {
+	l := make(Lock, 0, len(versions))
+	for name, v := range versions {
+		l = append(l, Entry{Name: name, Version: v})
+	}
+	l.sort()
+	return l
+}
+
+// Find returns the Entry of the named package, if any.
+func (l Lock) Find(name string) (Entry, bool) {
+	for _, e := range l {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Versions returns the version selected for each package.
+func (l Lock) Versions() map[string]semver.Number {
+	m := make(map[string]semver.Number, len(l))
+	for _, e := range l {
+		m[e.Name] = e.Version
+	}
+	return m
+}
+
+func (l Lock) sort() { sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name }) }
+
+// SyntaxError is the error produced when reading a malformed lockfile.
+type SyntaxError struct {
+	Line int
+	Err  error
+}
+
+// Unwrap returns the error SyntaxError is wrapping.
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+// Error satisfies the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("lockfile: line %d: %s", e.Line, e.Err.Error())
+}
+
+var (
+	errFields    = errors.New("expected a name, a version and an optional hash")
+	errDuplicate = errors.New("duplicate package")
+	errName      = errors.New("invalid package name")
+	errHash      = errors.New("invalid hash")
+)
+
+// Read parses a lockfile in the text format.
+// Blank lines and lines starting with '#' are ignored.
+func Read(r io.Reader) (Lock, error) {
+	var (
+		l    Lock
+		seen = make(map[string]bool)
+		s    = bufio.NewScanner(r)
+	)
+
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 || len(fields) > 3 {
+			return nil, &SyntaxError{line, errFields}
+		}
+		if seen[fields[0]] {
+			return nil, &SyntaxError{line, fmt.Errorf("%w: %s", errDuplicate, fields[0])}
+		}
+		seen[fields[0]] = true
+
+		v, err := semver.ParseNumber(fields[1])
+		if err != nil {
+			return nil, &SyntaxError{line, err}
+		}
+
+		e := Entry{Name: fields[0], Version: v}
+		if len(fields) == 3 {
+			e.Hash = fields[2]
+		}
+		l = append(l, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	l.sort()
+	return l, nil
+}
+
+// Write writes the Lock in the text format, sorted by package name.
+func Write(w io.Writer, l Lock) error {
+	sorted := append(Lock(nil), l...)
+	sorted.sort()
+
+	b := bufio.NewWriter(w)
+	b.WriteString(Header)
+	b.WriteByte('\n')
+	for i, e := range sorted {
+		if !validName(e.Name) {
+			return fmt.Errorf("lockfile: %w: %q", errName, e.Name)
+		}
+		if strings.ContainsAny(e.Hash, " \t\r\n") {
+			return fmt.Errorf("lockfile: %w: %q", errHash, e.Hash)
+		}
+		if i > 0 && sorted[i-1].Name == e.Name {
+			return fmt.Errorf("lockfile: %w: %s", errDuplicate, e.Name)
+		}
+		b.WriteString(e.Name)
+		b.WriteByte(' ')
+		b.WriteString(e.Version.GoString())
+		if e.Hash != "" {
+			b.WriteByte(' ')
+			b.WriteString(e.Hash)
+		}
+		b.WriteByte('\n')
+	}
+	return b.Flush()
+}
+
+func validName(name string) bool {
+	return name != "" && name[0] != '#' && !strings.ContainsAny(name, " \t\r\n")
+}
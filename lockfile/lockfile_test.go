@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/lockfile"
+)
+
+const text = `# semver32 lockfile v1
+auth 1.1.0 sha256:6b86b273ff34fce1
+host 1.4.0
+log 1.3.0 sha256:d4735e3a265e16ee
+`
+
+func TestReadWrite(t *testing.T) {
+	l, err := lockfile.Read(strings.NewReader("\n# comment\nlog 1.3 sha256:d4735e3a265e16ee\nhost 1.4.0\n  auth 1.1.0   sha256:6b86b273ff34fce1  \n"))
+	if err != nil {
+		t.Fatalf("no read error expected got: %s", err.Error())
+	}
+
+	var b bytes.Buffer
+	if err := lockfile.Write(&b, l); err != nil {
+		t.Fatalf("no write error expected got: %s", err.Error())
+	}
+	if got := b.String(); got != text {
+		t.Errorf("written lockfile mismatch expected: %s got: %s", text, got)
+	}
+
+	if e, ok := l.Find("log"); !ok || e.Version != semver.NewNumber(1, 3, 0) || e.Hash != "sha256:d4735e3a265e16ee" {
+		t.Errorf("found entry mismatch got: %+v", e)
+	}
+	if _, ok := l.Find("missing"); ok {
+		t.Error("expected missing entry NOT to be found")
+	}
+
+	if b, err := json.Marshal(l); err != nil {
+		t.Errorf("no json marshaling error expected got: %s", err.Error())
+	} else {
+		var un lockfile.Lock
+		if err := json.Unmarshal(b, &un); err != nil {
+			t.Errorf("no json unmarshaling error expected got: %s", err.Error())
+		} else if !reflect.DeepEqual(un, l) {
+			t.Errorf("json unmarshaling mismatch expected %v got: %v from %s", l, un, string(b))
+		}
+	}
+
+	if b, err := yaml.Marshal(l); err != nil {
+		t.Errorf("no yaml marshaling error expected got: %s", err.Error())
+	} else {
+		var un lockfile.Lock
+		if err := yaml.Unmarshal(b, &un); err != nil {
+			t.Errorf("no yaml unmarshaling error expected got: %s", err.Error())
+		} else if !reflect.DeepEqual(un, l) {
+			t.Errorf("yaml unmarshaling mismatch expected %v got: %v from %s", l, un, string(b))
+		}
+	}
+}
+
+func TestReadError(t *testing.T) {
+	var tcs = []struct {
+		input string
+		line  int
+	}{
+		{"auth\n", 1},
+		{"# header\nauth 1.0 hash extra\n", 2},
+		{"auth 1.0\nauth 1.1\n", 2},
+		{"auth 1.x\n", 1},
+	}
+
+	for i, tc := range tcs {
+		var se *lockfile.SyntaxError
+		if _, err := lockfile.Read(strings.NewReader(tc.input)); !errors.As(err, &se) {
+			t.Errorf("tc[%d] syntax error expected got: %v", i, err)
+		} else if se.Line != tc.line {
+			t.Errorf("tc[%d] syntax error line mismatch expected: %d got: %d", i, tc.line, se.Line)
+		}
+	}
+
+	var ice semver.ErrorInvalidCharacter
+	if _, err := lockfile.Read(strings.NewReader("auth 1.x\n")); !errors.As(err, &ice) {
+		t.Errorf("invalid character error expected got: %v", err)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	var tcs = []lockfile.Lock{
+		{{Name: "", Version: 1}},
+		{{Name: "two words", Version: 1}},
+		{{Name: "#auth", Version: 1}},
+		{{Name: "auth", Version: 1, Hash: "sha256: 1"}},
+		{{Name: "auth", Version: 1}, {Name: "auth", Version: 2}},
+	}
+
+	for i, tc := range tcs {
+		if err := lockfile.Write(&bytes.Buffer{}, tc); err == nil {
+			t.Errorf("tc[%d] write error expected got: nil", i)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	l := lockfile.New(map[string]semver.Number{
+		"log":  semver.NewNumber(1, 3, 0),
+		"auth": semver.NewNumber(1, 1, 0),
+	})
+
+	if exp, got := "[{auth 1.1 } {log 1.3 }]", fmt.Sprint(l); exp != got {
+		t.Errorf("new lock mismatch expected: %s got: %s", exp, got)
+	}
+	if exp, got := "map[auth:1.1 log:1.3]", fmt.Sprint(l.Versions()); exp != got {
+		t.Errorf("versions mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func ExampleWrite() {
+	l := lockfile.New(map[string]semver.Number{
+		"host": semver.NewNumber(1, 4, 0),
+		"auth": semver.NewNumber(1, 1, 0),
+	})
+	l[0].Hash = "sha256:6b86b273ff34fce1"
+
+	if err := lockfile.Write(os.Stdout, l); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// # semver32 lockfile v1
+	// auth 1.1.0 sha256:6b86b273ff34fce1
+	// host 1.4.0
+}
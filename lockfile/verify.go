@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile
+
+import (
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Violation describes a constraint that a Lock does not satisfy.
+type Violation struct {
+	Name   string
+	Ranges semver.Ranges
+	// Version is the locked version, unless Missing is true.
+	Version semver.Number
+	Missing bool
+}
+
+// String satisfies the fmt.Stringer interface.
+func (v Violation) String() string {
+	if v.Missing {
+		return v.Name + " is not locked, but " + v.Ranges.String() + " is required"
+	}
+	return v.Name + " is locked at " + v.Version.GoString() + ", but " + v.Ranges.String() + " is required"
+}
+
+// VerificationError is the error produced when a Lock
+// does not satisfy a set of constraints.
+type VerificationError struct{ Violations []Violation }
+
+// Error satisfies the error interface.
+func (e *VerificationError) Error() string {
+	var b strings.Builder
+	b.WriteString("lockfile: constraints not satisfied:")
+	for _, v := range e.Violations {
+		b.WriteString("\n\t")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// Verify checks that every constrained package is locked at a version
+// within its constraints, e.g.: resolve.Requirements.
+// It returns a *VerificationError listing every violation, if any.
+func Verify(l Lock, constraints map[string]semver.Ranges) error {
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var vs []Violation
+	for _, name := range names {
+		rs := constraints[name]
+		if e, ok := l.Find(name); !ok {
+			vs = append(vs, Violation{Name: name, Ranges: rs, Missing: true})
+		} else if !rs.Contains(e.Version) {
+			vs = append(vs, Violation{Name: name, Ranges: rs, Version: e.Version})
+		}
+	}
+
+	if len(vs) > 0 {
+		return &VerificationError{vs}
+	}
+	return nil
+}
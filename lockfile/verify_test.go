@@ -0,0 +1,46 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package lockfile_test
+
+import (
+	"errors"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/lockfile"
+)
+
+func TestVerify(t *testing.T) {
+	l := lockfile.Lock{
+		{Name: "auth", Version: semver.NewNumber(1, 1, 0)},
+		{Name: "host", Version: semver.NewNumber(1, 4, 0)},
+	}
+
+	constraints := func(kv ...string) map[string]semver.Ranges {
+		m := make(map[string]semver.Ranges)
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i]], _ = semver.ParseRanges(kv[i+1])
+		}
+		return m
+	}
+
+	if err := lockfile.Verify(l, constraints("auth", "^1", "host", ">=1.2")); err != nil {
+		t.Errorf("no verification error expected got: %s", err.Error())
+	}
+
+	err := lockfile.Verify(l, constraints("auth", "^2", "host", "^1", "log", "*"))
+
+	var ve *lockfile.VerificationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("verification error expected got: %v", err)
+	}
+	exp := "lockfile: constraints not satisfied:" +
+		"\n\tauth is locked at 1.1.0, but 2.0.0 - 2.255.255 is required" +
+		"\n\tlog is not locked, but 0.0.0 - 65535.255.255 is required"
+	if got := ve.Error(); got != exp {
+		t.Errorf("verification error mismatch expected: %s got: %s", exp, got)
+	}
+}
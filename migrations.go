@@ -0,0 +1,164 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"context"
+	"sort"
+)
+
+// MigrationFunc applies or reverts a migration.
+type MigrationFunc func(ctx context.Context) error
+
+// Migration is a step registered under the Number it migrates to.
+// Up migrates from the previous Number, Down reverts to it.
+type Migration struct {
+	Number   Number
+	Up, Down MigrationFunc
+}
+
+// Step is a Migration to be applied, or reverted when Up is false.
+type Step struct {
+	Migration
+	Up bool
+}
+
+// Migrations is a registry of Migration values, keyed by their Number.
+//
+// The zero value is an empty registry ready to use.
+type Migrations struct {
+	// AllowGaps disables the detection of gaps: by default, every
+	// Migration must belong to the same minor line as the previous one or
+	// to the line that follows it by a single bump, so that patch releases
+	// need no Migration; e.g.: 1.2.4 and 1.3.1 may follow 1.2.2, and so
+	// may 2.0.0, but 1.4.0 may not. Migrations may be registered in any
+	// order, each one is checked against its neighbours.
+	AllowGaps bool
+
+	steps []Migration
+}
+
+// Register adds a Migration under the specified Number.
+// down may be nil, in which case the Migration can't be reverted.
+//
+// It will produce an error if a Migration is already registered under the
+// same Number or if, unless AllowGaps is set, it would leave a gap.
+// It panics if up is nil.
+func (ms *Migrations) Register(n Number, up, down MigrationFunc) error {
+	if up == nil {
+		panic("semver: nil migration")
+	}
+
+	i := sort.Search(len(ms.steps), func(i int) bool { return ms.steps[i].Number >= n })
+	if i < len(ms.steps) && ms.steps[i].Number == n {
+		return &Error{ErrorDuplicateMigration(n.String())}
+	}
+	if !ms.AllowGaps {
+		if i > 0 && !follows(ms.steps[i-1].Number, n) {
+			return &Error{ErrorMigrationGap{ms.steps[i-1].Number, n}}
+		}
+		if i < len(ms.steps) && !follows(n, ms.steps[i].Number) {
+			return &Error{ErrorMigrationGap{n, ms.steps[i].Number}}
+		}
+	}
+
+	ms.steps = append(ms.steps, Migration{})
+	copy(ms.steps[i+1:], ms.steps[i:])
+	ms.steps[i] = Migration{n, up, down}
+	return nil
+}
+
+// follows reports whether b, being greater than a, belongs to the
+// minor line of a or to the one following it by a single bump.
+func follows(a, b Number) bool {
+	line := b.Truncate(PartMinor)
+	if line == a.Truncate(PartMinor) {
+		return true
+	}
+	for _, bump := range []func() (Number, error){a.BumpMinor, a.BumpMajor} {
+		if n, err := bump(); err == nil && n == line {
+			return true
+		}
+	}
+	return false
+}
+
+// Numbers returns the Numbers the Migration values
+// are registered under, in ascending order.
+func (ms *Migrations) Numbers() Numbers {
+	ns := make(Numbers, len(ms.steps))
+	for i, m := range ms.steps {
+		ns[i] = m.Number
+	}
+	return ns
+}
+
+// Plan returns the steps that migrate from one Number to another:
+// when upgrading, the Migration values registered after from and up to
+// to, in ascending order; when downgrading, the ones registered after to
+// and up to from, in descending order, to be reverted.
+//
+// It will produce an error if a Migration that can't be reverted
+// would have to be.
+func (ms *Migrations) Plan(from, to Number) ([]Step, error) {
+	var steps []Step
+	if from <= to {
+		for _, m := range ms.steps {
+			if from < m.Number && m.Number <= to {
+				steps = append(steps, Step{m, true})
+			}
+		}
+		return steps, nil
+	}
+
+	for i := len(ms.steps) - 1; i >= 0; i-- {
+		if m := ms.steps[i]; to < m.Number && m.Number <= from {
+			if m.Down == nil {
+				return nil, &Error{ErrorIrreversibleMigration(m.Number.String())}
+			}
+			steps = append(steps, Step{m, false})
+		}
+	}
+	return steps, nil
+}
+
+// Run applies the steps planned to migrate from one Number to another,
+// stopping at the first one that fails or when ctx is done.
+//
+// It returns the Number reached, which is to unless an error is produced.
+// Errors produced by the steps are wrapped in an ErrorMigrationFailed.
+func (ms *Migrations) Run(ctx context.Context, from, to Number) (Number, error) {
+	steps, err := ms.Plan(from, to)
+	if err != nil {
+		return from, err
+	}
+
+	reached := from
+	for i, s := range steps {
+		if err := ctx.Err(); err != nil {
+			return reached, err
+		}
+
+		if s.Up {
+			err = s.Migration.Up(ctx)
+		} else {
+			err = s.Migration.Down(ctx)
+		}
+		if err != nil {
+			return reached, &Error{ErrorMigrationFailed{s.Number, s.Up, err}}
+		}
+
+		switch {
+		case s.Up:
+			reached = s.Number
+		case i+1 < len(steps):
+			reached = steps[i+1].Number
+		default:
+			reached = to
+		}
+	}
+	return to, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+type journal []string
+
+func (j *journal) step(s string, err error) semver.MigrationFunc {
+	return func(context.Context) error {
+		*j = append(*j, s)
+		return err
+	}
+}
+
+func TestMigrationsRegister(t *testing.T) {
+	var (
+		j  journal
+		ms semver.Migrations
+	)
+
+	var tcs = []struct {
+		n   string
+		err error
+	}{
+		{"1.1", nil},
+		{"1.0.1", nil},
+		{"1.0", nil},
+		{"1.0.5", nil},
+		{"1.1", semver.ErrorDuplicateMigration("1.1")},
+		{"1.3", semver.ErrorMigrationGap{}},
+		{"1.1.2", nil},
+		{"3", semver.ErrorMigrationGap{}},
+		{"2", nil},
+	}
+
+	for i, tc := range tcs {
+		n, _ := semver.ParseNumber(tc.n)
+		err := ms.Register(n, j.step(tc.n, nil), nil)
+		if tc.err == nil {
+			if err != nil {
+				t.Errorf("tc[%d] no registration error expected got: %s", i, err.Error())
+			}
+		} else if e, ok := err.(*semver.Error); !ok || fmt.Sprintf("%T", e.Unwrap()) != fmt.Sprintf("%T", tc.err) {
+			t.Errorf("tc[%d] registration error mismatch expected: %T got: %v", i, tc.err, err)
+		}
+	}
+
+	if exp, got := "[1 1.0.1 1.0.5 1.1 1.1.2 2]", fmt.Sprint(ms.Numbers()); exp != got {
+		t.Errorf("numbers mismatch expected: %s got: %s", exp, got)
+	}
+
+	var later semver.Migrations
+	later.Register(semver.NewNumber(1, 2, 0), j.step("1.2", nil), nil)
+	err := later.Register(semver.NewNumber(1, 0, 0), j.step("1.0", nil), nil)
+	if exp := `semver: migration gap between "1.0.0" and "1.2.0"`; err == nil || err.Error() != exp {
+		t.Errorf("gap error mismatch expected: %s got: %v", exp, err)
+	}
+
+	gaps := semver.Migrations{AllowGaps: true}
+	for _, s := range []string{"2", "1", "1.5"} {
+		n, _ := semver.ParseNumber(s)
+		if err := gaps.Register(n, j.step(s, nil), nil); err != nil {
+			t.Errorf("no registration error expected got: %s", err.Error())
+		}
+	}
+	if exp, got := "[1 1.5 2]", fmt.Sprint(gaps.Numbers()); exp != got {
+		t.Errorf("numbers mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func newMigrations(j *journal, fail string) *semver.Migrations {
+	ms := &semver.Migrations{AllowGaps: true}
+	for _, s := range []string{"1", "1.1", "1.2", "2"} {
+		var err error
+		if s == fail {
+			err = errors.New("boom")
+		}
+		n, _ := semver.ParseNumber(s)
+		ms.Register(n, j.step("up "+s, err), j.step("down "+s, err))
+	}
+	return ms
+}
+
+func TestMigrationsRun(t *testing.T) {
+	var tcs = []struct {
+		from, to, fail string
+		journal        string
+		reached        string
+		failed         string
+	}{
+		{"0", "2", "", "up 1, up 1.1, up 1.2, up 2", "2", ""},
+		{"1", "1.5", "", "up 1.1, up 1.2", "1.5", ""},
+		{"1.1", "1.1", "", "", "1.1", ""},
+		{"2", "1", "", "down 2, down 1.2, down 1.1", "1", ""},
+		{"3", "0.5", "", "down 2, down 1.2, down 1.1, down 1", "0.5", ""},
+		{"0", "2", "1.2", "up 1, up 1.1, up 1.2", "1.1", "1.2"},
+		{"2", "0", "1.1", "down 2, down 1.2, down 1.1", "1.1", "1.1"},
+	}
+
+	for i, tc := range tcs {
+		var j journal
+		from, _ := semver.ParseNumber(tc.from)
+		to, _ := semver.ParseNumber(tc.to)
+
+		reached, err := newMigrations(&j, tc.fail).Run(context.Background(), from, to)
+		if got := strings.Join(j, ", "); got != tc.journal {
+			t.Errorf("tc[%d] journal mismatch expected: %s got: %s", i, tc.journal, got)
+		}
+		if got := reached.String(); got != tc.reached {
+			t.Errorf("tc[%d] reached mismatch expected: %s got: %s", i, tc.reached, got)
+		}
+
+		var mf semver.ErrorMigrationFailed
+		if tc.failed == "" {
+			if err != nil {
+				t.Errorf("tc[%d] no migration error expected got: %s", i, err.Error())
+			}
+		} else if !errors.As(err, &mf) || mf.Number().String() != tc.failed || mf.Unwrap().Error() != "boom" {
+			t.Errorf("tc[%d] migration failed error expected for %s got: %v", i, tc.failed, err)
+		}
+	}
+}
+
+func TestMigrationsIrreversible(t *testing.T) {
+	var (
+		j  journal
+		ms semver.Migrations
+	)
+	ms.Register(semver.NewNumber(1, 0, 0), j.step("up 1", nil), j.step("down 1", nil))
+	ms.Register(semver.NewNumber(1, 1, 0), j.step("up 1.1", nil), nil)
+
+	var im semver.ErrorIrreversibleMigration
+	if _, err := ms.Plan(semver.NewNumber(1, 1, 0), 0); !errors.As(err, &im) || im != "1.1" {
+		t.Errorf("irreversible migration error expected got: %v", err)
+	}
+	if reached, err := ms.Run(context.Background(), semver.NewNumber(1, 1, 0), 0); err == nil || reached != semver.NewNumber(1, 1, 0) || len(j) > 0 {
+		t.Errorf("no migration expected to run got: %v", j)
+	}
+	if steps, err := ms.Plan(semver.NewNumber(1, 0, 0), 0); err != nil || len(steps) != 1 || steps[0].Up {
+		t.Errorf("single down step expected got: %v %v", steps, err)
+	}
+}
+
+func TestMigrationsCanceled(t *testing.T) {
+	var j journal
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ms := newMigrations(&j, "")
+	ms.Register(semver.NewNumber(3, 0, 0), func(context.Context) error {
+		cancel()
+		return nil
+	}, nil)
+	ms.Register(semver.NewNumber(4, 0, 0), j.step("up 4", nil), nil)
+
+	reached, err := ms.Run(ctx, semver.NewNumber(2, 0, 0), semver.NewNumber(4, 0, 0))
+	if err != context.Canceled || reached != semver.NewNumber(3, 0, 0) || len(j) > 0 {
+		t.Errorf("canceled migration expected at 3 got: %s %v %v", reached, err, j)
+	}
+}
+
+func ExampleMigrations_Plan() {
+	var ms semver.Migrations
+	noop := func(context.Context) error { return nil }
+
+	ms.Register(semver.NewNumber(1, 0, 0), noop, noop)
+	ms.Register(semver.NewNumber(1, 1, 0), noop, noop)
+	ms.Register(semver.NewNumber(2, 0, 0), noop, noop)
+
+	steps, _ := ms.Plan(semver.NewNumber(2, 0, 0), semver.NewNumber(1, 0, 0))
+	for _, s := range steps {
+		fmt.Println(s.Number, s.Up)
+	}
+	// Output:
+	// 2 false
+	// 1.1 false
+}
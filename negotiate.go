@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Policy determines which Number two peers agree on when negotiating.
+type Policy byte
+
+const (
+	// PolicyExact agrees on the greatest Number supported by both peers.
+	PolicyExact Policy = iota
+	// PolicySameMajor agrees on the greatest major component supported by
+	// both peers and, within it, on the greatest Number supported by the
+	// client that is not greater than the greatest one supported by the
+	// server; assuming Numbers sharing their major component are compatible.
+	PolicySameMajor
+	// PolicyClientPreferred agrees on the greatest Number supported by the
+	// client whose major component is supported by the server.
+	PolicyClientPreferred
+)
+
+// Negotiate returns the Number agreed on by two peers
+// supporting the local and remote Ranges, according to the Policy.
+// When the Policy is PolicySameMajor or PolicyClientPreferred,
+// local is the client's.
+//
+// Numbers can be negotiated too by means of Numbers.Ranges.
+func Negotiate(local, remote Ranges, p Policy) (Number, error) {
+	var (
+		n  Number
+		ok bool
+	)
+
+	switch p {
+	case PolicyExact:
+		n, ok = local.Intersect(remote).Max()
+	case PolicySameMajor:
+		if n, ok = majors(local).Intersect(majors(remote)).Max(); ok {
+			line := Ranges{NewPattern(n, PartMajor).Range()}
+			r, _ := remote.Intersect(line).Max()
+			n, ok = local.Intersect(line).Intersect(Ranges{{0, r}}).Max()
+		}
+	case PolicyClientPreferred:
+		n, ok = local.Intersect(majors(remote)).Max()
+	}
+
+	if !ok {
+		return 0, &Error{ErrorNoCommonVersion{local, remote}}
+	}
+	return n, nil
+}
+
+// majors widens the Ranges so that they contain
+// every Number sharing the major component of the ones they contain.
+func majors(rs Ranges) Ranges {
+	ws := make(Ranges, len(rs))
+	for i, r := range rs {
+		ws[i] = Range{r.Min.Truncate(PartMajor), NewPattern(r.Max, PartMajor).Range().Max}
+	}
+	return ws.normalize()
+}
+
+// maxFrame is the size of the biggest frame a handshake may exchange:
+// 65,535 Range values, 8 bytes each, preceded by their count.
+const maxFrame = 2 + 8*65535
+
+// HandshakeClient negotiates a Number over rw, acting as the client.
+//
+// It sends the supported Ranges in their binary encoding, as a frame
+// preceded by its length in 4 bytes, big-endian; and waits for the server
+// to reply with the Number it agreed on, which must be a supported one.
+func HandshakeClient(rw io.ReadWriter, supported Ranges) (Number, error) {
+	hello, err := supported.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	if err := writeFrame(rw, hello); err != nil {
+		return 0, err
+	}
+
+	reply, err := readFrame(rw)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case len(reply) == 1 && reply[0] == 1:
+		return 0, &Error{ErrorNoCommonVersion{supported, nil}}
+	case len(reply) != 5 || reply[0] != 0:
+		return 0, &Error{ErrorHandshake("malformed reply")}
+	}
+
+	var n Number
+	if err := n.UnmarshalBinary(reply[1:]); err != nil {
+		return 0, err
+	}
+	if !supported.Contains(n) {
+		return 0, &Error{ErrorHandshake("server agreed on unsupported version " + n.GoString())}
+	}
+	return n, nil
+}
+
+// HandshakeServer negotiates a Number over rw, acting as the server.
+//
+// It waits for the Ranges supported by the client, negotiates a Number
+// according to the Policy and replies with it, as a frame holding a 0 byte
+// followed by the Number in its binary encoding; or, when there is none,
+// with a frame holding just a 1 byte.
+func HandshakeServer(rw io.ReadWriter, supported Ranges, p Policy) (Number, error) {
+	hello, err := readFrame(rw)
+	if err != nil {
+		return 0, err
+	}
+
+	var client Ranges
+	if err := client.UnmarshalBinary(hello); err != nil {
+		return 0, err
+	}
+
+	n, nerr := Negotiate(client, supported, p)
+	reply := []byte{1}
+	if nerr == nil {
+		b, _ := n.MarshalBinary()
+		reply = append([]byte{0}, b...)
+	}
+	if err := writeFrame(rw, reply); err != nil {
+		return 0, err
+	}
+	return n, nerr
+}
+
+func writeFrame(w io.Writer, payload []byte) error {
+	b := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(b, uint32(len(payload)))
+	copy(b[4:], payload)
+	_, err := w.Write(b)
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var l [4]byte
+	if _, err := io.ReadFull(r, l[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(l[:])
+	if size > maxFrame {
+		return nil, &Error{ErrorHandshake("frame too big")}
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func mustRanges(s string) semver.Ranges {
+	rs, err := semver.ParseRanges(s)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func TestNegotiate(t *testing.T) {
+	var tcs = []struct {
+		local, remote            string
+		exact, major, clientPref string
+	}{
+		{"1.0 || 1.2 || 2.0 - 2.3", "1.x || 2.1.0", "2.1", "2.1", "2.3.255"},
+		{"^1.2", "1.1 - 1.5", "1.5.255", "1.5.255", "1.255.255"},
+		{"1.0.0 - 1.4.0", "1.5 - 1.9", "", "1.4", "1.4"},
+		{"^3 || ^1", "^2 || 1.0.0", "1", "1", "1.255.255"},
+		{"^1", "^2", "", "", ""},
+		{"1.5.0 - 1.9.0", "1.0.0 - 1.2.0", "", "", "1.9"},
+	}
+
+	for i, tc := range tcs {
+		local, remote := mustRanges(tc.local), mustRanges(tc.remote)
+		for _, p := range []struct {
+			policy semver.Policy
+			exp    string
+		}{
+			{semver.PolicyExact, tc.exact},
+			{semver.PolicySameMajor, tc.major},
+			{semver.PolicyClientPreferred, tc.clientPref},
+		} {
+			n, err := semver.Negotiate(local, remote, p.policy)
+			if p.exp == "" {
+				var nce semver.ErrorNoCommonVersion
+				if !errors.As(err, &nce) {
+					t.Errorf("tc[%d] policy %d no common version error expected got: %v", i, p.policy, err)
+				}
+			} else if err != nil {
+				t.Errorf("tc[%d] policy %d no negotiation error expected got: %s", i, p.policy, err.Error())
+			} else if got := n.String(); got != p.exp {
+				t.Errorf("tc[%d] policy %d negotiated mismatch expected: %s got: %s", i, p.policy, p.exp, got)
+			}
+		}
+	}
+}
+
+func TestHandshake(t *testing.T) {
+	var tcs = []struct {
+		client, server string
+		policy         semver.Policy
+		exp            string
+	}{
+		{"1.0 || 1.2 || 2.0 - 2.3", "1.x || 2.1.0", semver.PolicyExact, "2.1"},
+		{"2.3.0", "2.1.0", semver.PolicyClientPreferred, "2.3"},
+		{"^1", "^2", semver.PolicyExact, ""},
+		{"1.0.0 - 1.4.0", "1.5 - 1.9", semver.PolicySameMajor, "1.4"},
+		{"1.5.0 - 1.9.0", "1.0.0 - 1.2.0", semver.PolicySameMajor, ""},
+	}
+
+	for i, tc := range tcs {
+		c, s := net.Pipe()
+
+		type result struct {
+			n   semver.Number
+			err error
+		}
+		done := make(chan result)
+		go func() {
+			n, err := semver.HandshakeServer(s, mustRanges(tc.server), tc.policy)
+			done <- result{n, err}
+		}()
+
+		n, err := semver.HandshakeClient(c, mustRanges(tc.client))
+		sr := <-done
+		c.Close()
+		s.Close()
+
+		if tc.exp == "" {
+			var nce semver.ErrorNoCommonVersion
+			if !errors.As(err, &nce) || !errors.As(sr.err, &nce) {
+				t.Errorf("tc[%d] no common version errors expected got: %v and %v", i, err, sr.err)
+			}
+			continue
+		}
+		if err != nil || sr.err != nil {
+			t.Errorf("tc[%d] no handshake errors expected got: %v and %v", i, err, sr.err)
+			continue
+		}
+		if n != sr.n || n.String() != tc.exp {
+			t.Errorf("tc[%d] handshake mismatch expected: %s got: %s and %s", i, tc.exp, n, sr.n)
+		}
+	}
+}
+
+type rw struct {
+	io.Reader
+	io.Writer
+}
+
+func TestHandshakeError(t *testing.T) {
+	frame := func(payload ...byte) []byte {
+		return append([]byte{0, 0, 0, byte(len(payload))}, payload...)
+	}
+
+	var tcs = []struct {
+		reply []byte
+		err   error
+	}{
+		{frame(2), semver.ErrorHandshake("")},
+		{frame(0, 0, 1), semver.ErrorHandshake("")},
+		{frame(0, 0, 2, 0, 0), semver.ErrorHandshake("")},
+		{[]byte{0xff, 0, 0, 0}, semver.ErrorHandshake("")},
+	}
+
+	for i, tc := range tcs {
+		_, err := semver.HandshakeClient(rw{bytes.NewReader(tc.reply), ioutil.Discard}, mustRanges("^1"))
+		if e, ok := err.(*semver.Error); !ok || fmt.Sprintf("%T", e.Unwrap()) != fmt.Sprintf("%T", tc.err) {
+			t.Errorf("tc[%d] handshake error mismatch expected: %T got: %v", i, tc.err, err)
+		}
+	}
+
+	if _, err := semver.HandshakeClient(rw{bytes.NewReader(frame(0)[:2]), ioutil.Discard}, mustRanges("^1")); err != io.ErrUnexpectedEOF {
+		t.Errorf("unexpected EOF expected got: %v", err)
+	}
+	if _, err := semver.HandshakeServer(rw{bytes.NewReader(frame(0, 1)), ioutil.Discard}, mustRanges("^1"), semver.PolicyExact); err == nil {
+		t.Error("invalid length error expected got: nil")
+	}
+}
+
+func ExampleNegotiate() {
+	client := semver.Numbers{semver.NewNumber(1, 4, 0), semver.NewNumber(2, 0, 0), semver.NewNumber(2, 1, 0)}
+	server, _ := semver.ParseRanges("^1 || 2.0.x")
+
+	n, err := semver.Negotiate(client.Ranges(), server, semver.PolicyExact)
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(n)
+	}
+	// Output: 2
+}
@@ -7,9 +7,12 @@ package semver
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 )
@@ -69,6 +72,25 @@ func (n Number) BumpPatch() (Number, error) {
 	return n&invPatchMask | Number(n.Patch()+1), nil
 }
 
+// Bump returns a new Number with the specified component increased by 1,
+// just like BumpMajor, BumpMinor or BumpPatch would.
+// Bumping the zero Part returns the same Number.
+func (n Number) Bump(p Part) (Number, error) {
+	switch p {
+	case PartMajor:
+		return n.BumpMajor()
+	case PartMinor:
+		return n.BumpMinor()
+	case PartPatch:
+		return n.BumpPatch()
+	}
+	return n, nil
+}
+
+// Truncate returns a new Number with the components after
+// the specified one set to 0, e.g.: 1.2.3 truncated to PartMinor is 1.2.0.
+func (n Number) Truncate(p Part) Number { return n & p.mask() }
+
 // Major returns the major component of the Number.
 func (n Number) Major() uint16 { return uint16(n >> 16 & invMajorMask) }
 
@@ -111,6 +133,14 @@ func (n Number) MarshalText() ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+// The Number is encoded as 4 bytes in big-endian order.
+func (n Number) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(n))
+	return b, nil
+}
+
 // UnmarshalYAML satisfies the gopkg.in/yaml.v3.Unmarshaler interface.
 func (n *Number) UnmarshalYAML(value *yaml.Node) error {
 	nn, err := ParseNumber(value.Value)
@@ -166,6 +196,48 @@ func (n *Number) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (n *Number) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return &Error{ErrorInvalidLength(len(data))}
+	}
+	*n = Number(binary.BigEndian.Uint32(data))
+	return nil
+}
+
+// Scan satisfies the fmt.Scanner interface, so that Numbers can be read
+// with fmt.Fscan and friends. The verbs %v and %s read its text
+// representation, e.g. "1.2.3", while %d reads its packed integer, e.g. 66051.
+// Running out of input produces io.ErrUnexpectedEOF.
+func (n *Number) Scan(state fmt.ScanState, verb rune) error {
+	if verb != 'v' && verb != 's' && verb != 'd' {
+		return &Error{ErrorInvalidVerb(verb)}
+	}
+
+	tok, err := state.Token(true, func(r rune) bool { return !unicode.IsSpace(r) })
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 {
+		return io.EOF
+	}
+
+	if verb != 'd' {
+		return n.UnmarshalText(tok)
+	}
+	for _, c := range tok {
+		if c < '0' || c > '9' {
+			return &Error{ErrorInvalidCharacter{string(tok), c}}
+		}
+	}
+	u, err := strconv.ParseUint(string(tok), 10, 32)
+	if err != nil {
+		return &Error{ErrorPackedTooBig(tok)}
+	}
+	*n = Number(u)
+	return nil
+}
+
 // ParseNumber takes a string, parses it and
 // returns a Number if parsing was successful.
 func ParseNumber(s string) (Number, error) {
@@ -208,6 +280,34 @@ func NewNumber(major uint16, minor, patch byte) Number {
 	return Number(major)<<16 | Number(minor)<<8 | Number(patch)
 }
 
+// MaxNumber is the biggest Number that can be represented: 65535.255.255.
+const MaxNumber Number = majorMask | minorMask | patchMask
+
+// Compare returns -1 if a is lower than b, 0 if they are equal
+// and +1 if a is greater than b.
+//
+// Its signature matches the one expected by slices.SortFunc.
+func Compare(a, b Number) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareAt compares a and b like Compare does,
+// but only takes into account the components up to and including p.
+func CompareAt(a, b Number, p Part) int { return Compare(a.Truncate(p), b.Truncate(p)) }
+
+// SameMajor reports whether a and b share their major component.
+func SameMajor(a, b Number) bool { return a&majorMask == b&majorMask }
+
+// SameMinorLine reports whether a and b share both
+// their major and minor components.
+func SameMinorLine(a, b Number) bool { return a&invPatchMask == b&invPatchMask }
+
 const majorMask Number = 0b11111111111111110000000000000000
 const minorMask Number = 0b00000000000000001111111100000000
 const patchMask Number = 0b00000000000000000000000011111111
@@ -8,7 +8,9 @@ package semver_test
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -210,6 +212,18 @@ func TestNumber(t *testing.T) {
 			t.Errorf("tc[%d] no json marshaling error expected got: %s", i, err.Error())
 		}
 
+		if b, err := n.MarshalBinary(); err == nil {
+			var un semver.Number
+
+			if err := un.UnmarshalBinary(b); err != nil {
+				t.Errorf("tc[%d] no binary unmarshaling error expected got: %s", i, err.Error())
+			} else if n != un {
+				t.Errorf("tc[%d] binary unmarshaling mismatch expected %s got: %s from %v", i, n, un, b)
+			}
+		} else {
+			t.Errorf("tc[%d] no binary marshaling error expected got: %s", i, err.Error())
+		}
+
 		if b, err := n.MarshalText(); err == nil {
 			if exp, got := tc.str, string(b); exp != got {
 				t.Errorf("tc[%d] text mismatch expected %s got: %s", i, exp, got)
@@ -229,6 +243,154 @@ func TestNumber(t *testing.T) {
 	}
 }
 
+func TestBump(t *testing.T) {
+	n := semver.NewNumber(1, 2, 3)
+
+	var tcs = []struct {
+		p   semver.Part
+		exp semver.Number
+	}{
+		{0, semver.NewNumber(1, 2, 3)},
+		{semver.PartMajor, semver.NewNumber(2, 0, 0)},
+		{semver.PartMinor, semver.NewNumber(1, 3, 0)},
+		{semver.PartPatch, semver.NewNumber(1, 2, 4)},
+	}
+
+	for i, tc := range tcs {
+		if got, err := n.Bump(tc.p); err != nil {
+			t.Errorf("tc[%d] no bump error expected got: %s", i, err.Error())
+		} else if got != tc.exp {
+			t.Errorf("tc[%d] bumped %s mismatch expected: %s got: %s", i, tc.p, tc.exp, got)
+		}
+	}
+
+	var te semver.ErrorPatchTooBig
+	if _, err := semver.NewNumber(1, 2, 255).Bump(semver.PartPatch); !errors.As(err, &te) {
+		t.Errorf("patch too big error expected got: %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	n := semver.NewNumber(1, 2, 3)
+
+	var tcs = []struct {
+		p   semver.Part
+		exp semver.Number
+	}{
+		{0, 0},
+		{semver.PartMajor, semver.NewNumber(1, 0, 0)},
+		{semver.PartMinor, semver.NewNumber(1, 2, 0)},
+		{semver.PartPatch, semver.NewNumber(1, 2, 3)},
+	}
+
+	for i, tc := range tcs {
+		if got := n.Truncate(tc.p); got != tc.exp {
+			t.Errorf("tc[%d] truncated to %s mismatch expected: %s got: %s", i, tc.p, tc.exp, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var tcs = []struct {
+		a, b                 semver.Number
+		cmp, major, minor    int
+		sameMajor, sameMinor bool
+	}{
+		{semver.NewNumber(1, 2, 3), semver.NewNumber(1, 2, 3), 0, 0, 0, true, true},
+		{semver.NewNumber(1, 2, 3), semver.NewNumber(1, 2, 4), -1, 0, 0, true, true},
+		{semver.NewNumber(1, 3, 0), semver.NewNumber(1, 2, 4), 1, 0, 1, true, false},
+		{semver.NewNumber(1, 255, 255), semver.NewNumber(2, 0, 0), -1, -1, -1, false, false},
+		{semver.NewNumber(3, 0, 0), semver.NewNumber(2, 9, 9), 1, 1, 1, false, false},
+	}
+
+	for i, tc := range tcs {
+		if got := semver.Compare(tc.a, tc.b); got != tc.cmp {
+			t.Errorf("tc[%d] compare mismatch expected: %d got: %d", i, tc.cmp, got)
+		}
+		if got := semver.Compare(tc.b, tc.a); got != -tc.cmp {
+			t.Errorf("tc[%d] reversed compare mismatch expected: %d got: %d", i, -tc.cmp, got)
+		}
+		if got := semver.CompareAt(tc.a, tc.b, semver.PartMajor); got != tc.major {
+			t.Errorf("tc[%d] compare at major mismatch expected: %d got: %d", i, tc.major, got)
+		}
+		if got := semver.CompareAt(tc.a, tc.b, semver.PartMinor); got != tc.minor {
+			t.Errorf("tc[%d] compare at minor mismatch expected: %d got: %d", i, tc.minor, got)
+		}
+		if got := semver.CompareAt(tc.a, tc.b, semver.PartPatch); got != tc.cmp {
+			t.Errorf("tc[%d] compare at patch mismatch expected: %d got: %d", i, tc.cmp, got)
+		}
+		if got := semver.SameMajor(tc.a, tc.b); got != tc.sameMajor {
+			t.Errorf("tc[%d] same major mismatch expected: %t got: %t", i, tc.sameMajor, got)
+		}
+		if got := semver.SameMinorLine(tc.a, tc.b); got != tc.sameMinor {
+			t.Errorf("tc[%d] same minor line mismatch expected: %t got: %t", i, tc.sameMinor, got)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	var tcs = []struct {
+		input  string
+		format string
+		exp    semver.Number
+		err    error
+	}{
+		{"1.2.3", "%v", semver.NewNumber(1, 2, 3), nil},
+		{"  1.2\n", "%s", semver.NewNumber(1, 2, 0), nil},
+		{"66051", "%d", semver.NewNumber(1, 2, 3), nil},
+		{"4294967295", "%d", semver.MaxNumber, nil},
+		{"4294967296", "%d", 0, semver.ErrorPackedTooBig("")},
+		{"99999999999", "%d", 0, semver.ErrorPackedTooBig("")},
+		{"1.2.3", "%d", 0, semver.ErrorInvalidCharacter{}},
+		{"1.2.x", "%v", 0, semver.ErrorInvalidCharacter{}},
+		{"1.256", "%v", 0, semver.ErrorMinorTooBig("")},
+		{"1.2.3", "%x", 0, semver.ErrorInvalidVerb('x')},
+	}
+
+	for i, tc := range tcs {
+		var n semver.Number
+		_, err := fmt.Sscanf(tc.input, tc.format, &n)
+		if tc.err == nil && err != nil {
+			t.Errorf("tc[%d] %q unexpected error: %v", i, tc.input, err)
+			continue
+		}
+		if tc.err != nil {
+			var e *semver.Error
+			if !errors.As(err, &e) || fmt.Sprintf("%T", errors.Unwrap(e)) != fmt.Sprintf("%T", tc.err) {
+				t.Errorf("tc[%d] %q error mismatch expected: %T got: %v", i, tc.input, tc.err, err)
+			}
+			continue
+		}
+		if n != tc.exp {
+			t.Errorf("tc[%d] %q mismatch expected: %#v got: %#v", i, tc.input, tc.exp, n)
+		}
+	}
+
+	var (
+		r  = strings.NewReader("1.0 1.2.3\n\t2.0.1\n")
+		ns semver.Numbers
+	)
+	for {
+		var n semver.Number
+		if _, err := fmt.Fscan(r, &n); err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		ns = append(ns, n)
+	}
+	if len(ns) != 3 || ns[2] != semver.NewNumber(2, 0, 1) {
+		t.Errorf("Fscan mismatch expected: [1 1.2.3 2.0.1] got: %v", ns)
+	}
+}
+
+func ExampleNumber_Scan() {
+	var a, b semver.Number
+	fmt.Sscan("1.2.3 2.0", &a, &b)
+	fmt.Println(a, b)
+	// Output: 1.2.3 2
+}
+
 func ExampleNewNumber() {
 	n := semver.NewNumber(0, 1, 0)
 	fmt.Printf("%d => %s", n, n)
@@ -292,6 +454,20 @@ func ExampleNumber_BumpPatch() {
 	// Output: 258 is 0.1.2
 }
 
+func ExampleNumber_Truncate() {
+	n := semver.NewNumber(1, 2, 3)
+
+	fmt.Println(n.Truncate(semver.PartMinor))
+	// Output: 1.2
+}
+
+func ExampleCompareAt() {
+	a, b := semver.NewNumber(1, 2, 3), semver.NewNumber(1, 2, 9)
+
+	fmt.Println(semver.Compare(a, b), semver.CompareAt(a, b, semver.PartMinor))
+	// Output: -1 0
+}
+
 func ExampleNumber_Major() {
 	n := semver.NewNumber(1, 2, 3)
 
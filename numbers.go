@@ -5,6 +5,8 @@
 
 package semver
 
+import "sort"
+
 // Numbers is a slice of Number.
 type Numbers []Number
 
@@ -16,3 +18,61 @@ func (ns Numbers) Swap(i, j int) { ns[i], ns[j] = ns[j], ns[i] }
 
 // Less is implemented so that Numbers satisfies sort.Interface
 func (ns Numbers) Less(i, j int) bool { return ns[i] < ns[j] }
+
+// GroupBy groups the Numbers into release lines, keyed by the Numbers
+// truncated to the specified component; e.g.: grouped by PartMinor,
+// 1.2.0 and 1.2.5 belong to the line keyed 1.2.0.
+//
+// The Numbers within each line are sorted in ascending order.
+func (ns Numbers) GroupBy(p Part) map[Number]Numbers {
+	groups := make(map[Number]Numbers)
+	for _, n := range ns.sorted() {
+		k := n.Truncate(p)
+		groups[k] = append(groups[k], n)
+	}
+	return groups
+}
+
+// Lines returns the distinct release lines of the Numbers
+// truncated to the specified component, sorted in ascending order.
+func (ns Numbers) Lines(p Part) Numbers {
+	var lines Numbers
+	for _, n := range ns.sorted() {
+		if k := n.Truncate(p); len(lines) == 0 || lines[len(lines)-1] != k {
+			lines = append(lines, k)
+		}
+	}
+	return lines
+}
+
+// LatestPerLine returns the greatest Number of each release line of
+// the Numbers truncated to the specified component,
+// sorted in ascending order.
+func (ns Numbers) LatestPerLine(p Part) Numbers {
+	var latest Numbers
+	for _, n := range ns.sorted() {
+		if l := len(latest); l > 0 && latest[l-1].Truncate(p) == n.Truncate(p) {
+			latest[l-1] = n
+		} else {
+			latest = append(latest, n)
+		}
+	}
+	return latest
+}
+
+// Ranges returns the Ranges containing exactly the Numbers.
+func (ns Numbers) Ranges() Ranges {
+	rs := make(Ranges, len(ns))
+	for i, n := range ns {
+		rs[i] = Range{n, n}
+	}
+	return rs.normalize()
+}
+
+// sorted returns a sorted copy of the Numbers.
+func (ns Numbers) sorted() Numbers {
+	s := make(Numbers, len(ns))
+	copy(s, ns)
+	sort.Sort(s)
+	return s
+}
@@ -6,6 +6,7 @@
 package semver_test
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
@@ -29,3 +30,92 @@ func TestNumbers(t *testing.T) {
 		t.Error("expected numbers to be sorted")
 	}
 }
+
+func TestNumbersLines(t *testing.T) {
+	numbers := semver.Numbers{
+		semver.NewNumber(2, 1, 0),
+		semver.NewNumber(1, 0, 0),
+		semver.NewNumber(1, 2, 3),
+		semver.NewNumber(1, 2, 0),
+		semver.NewNumber(2, 0, 4),
+		semver.NewNumber(1, 0, 1),
+		semver.NewNumber(2, 1, 0),
+	}
+
+	var tcs = []struct {
+		p      semver.Part
+		lines  string
+		latest string
+		groups map[string]string
+	}{
+		{
+			semver.PartMajor, "[1 2]", "[1.2.3 2.1]",
+			map[string]string{
+				"1": "[1 1.0.1 1.2 1.2.3]",
+				"2": "[2.0.4 2.1 2.1]",
+			},
+		},
+		{
+			semver.PartMinor, "[1 1.2 2 2.1]", "[1.0.1 1.2.3 2.0.4 2.1]",
+			map[string]string{
+				"1":   "[1 1.0.1]",
+				"1.2": "[1.2 1.2.3]",
+				"2":   "[2.0.4]",
+				"2.1": "[2.1 2.1]",
+			},
+		},
+		{
+			semver.PartPatch, "[1 1.0.1 1.2 1.2.3 2.0.4 2.1]", "[1 1.0.1 1.2 1.2.3 2.0.4 2.1]",
+			nil,
+		},
+	}
+
+	for i, tc := range tcs {
+		if got := fmt.Sprint(numbers.Lines(tc.p)); got != tc.lines {
+			t.Errorf("tc[%d] lines mismatch expected: %s got: %s", i, tc.lines, got)
+		}
+		if got := fmt.Sprint(numbers.LatestPerLine(tc.p)); got != tc.latest {
+			t.Errorf("tc[%d] latest per line mismatch expected: %s got: %s", i, tc.latest, got)
+		}
+		if tc.groups == nil {
+			continue
+		}
+		groups := numbers.GroupBy(tc.p)
+		if len(groups) != len(tc.groups) {
+			t.Errorf("tc[%d] group count mismatch expected: %d got: %d", i, len(tc.groups), len(groups))
+		}
+		for k, g := range groups {
+			if exp, got := tc.groups[k.String()], fmt.Sprint(g); exp != got {
+				t.Errorf("tc[%d] group %s mismatch expected: %s got: %s", i, k, exp, got)
+			}
+		}
+	}
+
+	if numbers[0] != semver.NewNumber(2, 1, 0) {
+		t.Error("expected numbers NOT to be modified")
+	}
+}
+
+func TestNumbersRanges(t *testing.T) {
+	numbers := semver.Numbers{
+		semver.NewNumber(1, 0, 1),
+		semver.NewNumber(2, 0, 0),
+		semver.NewNumber(1, 0, 0),
+	}
+
+	if exp, got := "1.0.0 - 1.0.1 || 2.0.0 - 2.0.0", numbers.Ranges().String(); exp != got {
+		t.Errorf("ranges mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func ExampleNumbers_LatestPerLine() {
+	numbers := semver.Numbers{
+		semver.NewNumber(1, 4, 0),
+		semver.NewNumber(1, 4, 2),
+		semver.NewNumber(1, 5, 1),
+		semver.NewNumber(2, 0, 0),
+	}
+
+	fmt.Println(numbers.LatestPerLine(semver.PartMinor))
+	// Output: [1.4.2 1.5.1 2]
+}
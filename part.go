@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+// Part identifies one of the components of a Number.
+// The zero Part refers to none of them.
+type Part byte
+
+const (
+	// PartMajor identifies the major component of a Number.
+	PartMajor Part = iota + 1
+	// PartMinor identifies the minor component of a Number.
+	PartMinor
+	// PartPatch identifies the patch component of a Number.
+	PartPatch
+)
+
+// String satisfies the fmt.Stringer interface.
+func (p Part) String() string {
+	switch p {
+	case 0:
+		return "none"
+	case PartMajor:
+		return "major"
+	case PartMinor:
+		return "minor"
+	case PartPatch:
+		return "patch"
+	}
+	return "invalid"
+}
+
+// mask returns a mask that keeps the components up to and including p.
+func (p Part) mask() Number {
+	switch p {
+	case 0:
+		return 0
+	case PartMajor:
+		return majorMask
+	case PartMinor:
+		return invPatchMask
+	}
+	return MaxNumber
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pattern represents a partial Number in which the trailing components
+// may be wildcards, e.g.: "1.x", "1.2.*" or "*".
+//
+// Omitted components are wildcards too, so "1.2" matches the same Numbers
+// as "1.2.x".
+type Pattern struct {
+	n     Number
+	fixed Part
+}
+
+// NewPattern creates a Pattern out of the components of n up to and
+// including fixed; the remaining ones are wildcards.
+func NewPattern(n Number, fixed Part) Pattern {
+	if fixed > PartPatch {
+		fixed = PartPatch
+	}
+	return Pattern{n.Truncate(fixed), fixed}
+}
+
+// Fixed returns the last component of the Pattern that is not a wildcard.
+// It returns the zero Part when every component is a wildcard.
+func (p Pattern) Fixed() Part { return p.fixed }
+
+// IsFixed reports whether the specified component is not a wildcard.
+func (p Pattern) IsFixed(part Part) bool { return part != 0 && part <= p.fixed }
+
+// Number returns the smallest Number matched by the Pattern.
+func (p Pattern) Number() Number { return p.n }
+
+// Match reports whether n has the same fixed components as the Pattern.
+func (p Pattern) Match(n Number) bool { return n.Truncate(p.fixed) == p.n }
+
+// Range returns the Range of the Numbers matched by the Pattern.
+func (p Pattern) Range() Range { return Range{p.n, p.n | ^p.fixed.mask()} }
+
+// String satisfies the fmt.Stringer interface.
+func (p Pattern) String() string {
+	var b strings.Builder
+	p.write(&b)
+	return b.String()
+}
+
+// MarshalYAML satisfies the gopkg.in/yaml.v3.Marshaler interface.
+func (p Pattern) MarshalYAML() (interface{}, error) {
+	return p.String(), nil
+}
+
+// MarshalJSON satisfies the encoding/json.Marshaler interface.
+func (p Pattern) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('"')
+	p.write(&b)
+	b.WriteByte('"')
+	return b.Bytes(), nil
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (p Pattern) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+	p.write(&b)
+	return b.Bytes(), nil
+}
+
+// UnmarshalYAML satisfies the gopkg.in/yaml.v3.Unmarshaler interface.
+func (p *Pattern) UnmarshalYAML(value *yaml.Node) error {
+	pp, err := ParsePattern(value.Value)
+	if err != nil {
+		return err
+	}
+	*p = pp
+	return nil
+}
+
+// UnmarshalJSON satisfies the encoding/json.Unmarshaler interface.
+func (p *Pattern) UnmarshalJSON(data []byte) error {
+	if len(data) > 2 {
+		return p.UnmarshalText(data[1 : len(data)-1])
+	}
+	return errorEmpty
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (p *Pattern) UnmarshalText(text []byte) error {
+	pp, err := ParsePattern(string(text))
+	if err != nil {
+		return err
+	}
+	*p = pp
+	return nil
+}
+
+func (p Pattern) write(b interface {
+	WriteString(string) (n int, err error)
+	WriteByte(byte) error
+}) {
+	if p.fixed == 0 {
+		b.WriteByte('*')
+		return
+	}
+	b.WriteString(strconv.Itoa(int(p.n.Major())))
+	if p.fixed < PartMinor {
+		b.WriteString(".x")
+		return
+	}
+	b.WriteByte('.')
+	b.WriteString(strconv.Itoa(int(p.n.Minor())))
+	if p.fixed < PartPatch {
+		b.WriteString(".x")
+		return
+	}
+	b.WriteByte('.')
+	b.WriteString(strconv.Itoa(int(p.n.Patch())))
+}
+
+// ParsePattern takes a string, parses it and
+// returns a Pattern if parsing was successful.
+//
+// Wildcards may be written as 'x', 'X' or '*' and,
+// once one is found, every following component must be a wildcard too.
+func ParsePattern(s string) (Pattern, error) {
+	if len(s) == 0 {
+		return Pattern{}, errorEmpty
+	}
+
+	var (
+		c        [3]Number
+		fixed    Part
+		wildcard bool
+	)
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Pattern{}, &Error{ErrorInvalidCharacter{s, '.'}}
+	}
+
+	for i, part := range parts {
+		if len(part) == 0 {
+			return Pattern{}, &Error{ErrorInvalidCharacter{s, '.'}}
+		}
+		if len(part) == 1 && isWildcard(part[0]) {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return Pattern{}, &Error{ErrorInvalidCharacter{s, part[0]}}
+		}
+		for j := 0; j < len(part); j++ {
+			ch := part[j]
+			if ch < '0' || ch > '9' {
+				return Pattern{}, &Error{ErrorInvalidCharacter{s, ch}}
+			}
+			switch c[i] = c[i]*10 + Number(ch-'0'); {
+			case i == 0 && c[i] > 65535:
+				return Pattern{}, &Error{ErrorMajorTooBig(s)}
+			case i == 1 && c[i] > 255:
+				return Pattern{}, &Error{ErrorMinorTooBig(s)}
+			case i == 2 && c[i] > 255:
+				return Pattern{}, &Error{ErrorPatchTooBig(s)}
+			}
+		}
+		fixed++
+	}
+
+	return Pattern{c[0]<<16 | c[1]<<8 | c[2], fixed}, nil
+}
+
+func isWildcard(c byte) bool { return c == 'x' || c == 'X' || c == '*' }
@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestPattern(t *testing.T) {
+	var tcs = []struct {
+		input, str string
+		fixed      semver.Part
+		min, max   semver.Number
+		match      []semver.Number
+		noMatch    []semver.Number
+	}{
+		{
+			"*", "*", 0, 0, semver.MaxNumber,
+			[]semver.Number{0, semver.NewNumber(1, 2, 3), semver.MaxNumber}, nil,
+		},
+		{
+			"x.x.x", "*", 0, 0, semver.MaxNumber,
+			[]semver.Number{0, semver.MaxNumber}, nil,
+		},
+		{
+			"1.x", "1.x", semver.PartMajor, semver.NewNumber(1, 0, 0), semver.NewNumber(1, 255, 255),
+			[]semver.Number{semver.NewNumber(1, 0, 0), semver.NewNumber(1, 255, 255)},
+			[]semver.Number{semver.NewNumber(0, 255, 255), semver.NewNumber(2, 0, 0)},
+		},
+		{
+			"1", "1.x", semver.PartMajor, semver.NewNumber(1, 0, 0), semver.NewNumber(1, 255, 255),
+			[]semver.Number{semver.NewNumber(1, 4, 2)},
+			[]semver.Number{semver.NewNumber(2, 4, 2)},
+		},
+		{
+			"1.2.*", "1.2.x", semver.PartMinor, semver.NewNumber(1, 2, 0), semver.NewNumber(1, 2, 255),
+			[]semver.Number{semver.NewNumber(1, 2, 0), semver.NewNumber(1, 2, 255)},
+			[]semver.Number{semver.NewNumber(1, 1, 255), semver.NewNumber(1, 3, 0)},
+		},
+		{
+			"1.2.X", "1.2.x", semver.PartMinor, semver.NewNumber(1, 2, 0), semver.NewNumber(1, 2, 255),
+			nil, nil,
+		},
+		{
+			"1.2", "1.2.x", semver.PartMinor, semver.NewNumber(1, 2, 0), semver.NewNumber(1, 2, 255),
+			nil, nil,
+		},
+		{
+			"1.2.3", "1.2.3", semver.PartPatch, semver.NewNumber(1, 2, 3), semver.NewNumber(1, 2, 3),
+			[]semver.Number{semver.NewNumber(1, 2, 3)},
+			[]semver.Number{semver.NewNumber(1, 2, 4)},
+		},
+	}
+
+	for i, tc := range tcs {
+		p, err := semver.ParsePattern(tc.input)
+		if err != nil {
+			t.Errorf("tc[%d] no pattern parsing error expected, got: %s", i, err.Error())
+			continue
+		}
+
+		if s := p.String(); s != tc.str {
+			t.Errorf("tc[%d] string mismatch expected: %s got: %s", i, tc.str, s)
+		}
+		if f := p.Fixed(); f != tc.fixed {
+			t.Errorf("tc[%d] fixed part mismatch expected: %s got: %s", i, tc.fixed, f)
+		}
+		for _, part := range []semver.Part{semver.PartMajor, semver.PartMinor, semver.PartPatch} {
+			if exp, got := part <= tc.fixed, p.IsFixed(part); exp != got {
+				t.Errorf("tc[%d] is %s fixed mismatch expected: %t got: %t", i, part, exp, got)
+			}
+		}
+		if r := p.Range(); r.Min != tc.min || r.Max != tc.max {
+			t.Errorf("tc[%d] range mismatch expected: %s - %s got: %s", i, tc.min, tc.max, r)
+		}
+		if n := p.Number(); n != tc.min {
+			t.Errorf("tc[%d] number mismatch expected: %s got: %s", i, tc.min, n)
+		}
+		for _, n := range tc.match {
+			if !p.Match(n) {
+				t.Errorf("tc[%d] expected %s to match %s", i, p, n)
+			}
+		}
+		for _, n := range tc.noMatch {
+			if p.Match(n) {
+				t.Errorf("tc[%d] expected %s NOT to match %s", i, p, n)
+			}
+		}
+
+		if np := semver.NewPattern(p.Range().Max, p.Fixed()); np != p {
+			t.Errorf("tc[%d] new pattern mismatch expected: %s got: %s", i, p, np)
+		}
+
+		if b, err := json.Marshal(p); err != nil {
+			t.Errorf("tc[%d] no json marshaling error expected got: %s", i, err.Error())
+		} else {
+			var un semver.Pattern
+			if err := json.Unmarshal(b, &un); err != nil {
+				t.Errorf("tc[%d] no json unmarshaling error expected got: %s", i, err.Error())
+			} else if un != p {
+				t.Errorf("tc[%d] json unmarshaling mismatch expected %s got: %s from %s", i, p, un, string(b))
+			}
+		}
+
+		if b, err := yaml.Marshal(p); err != nil {
+			t.Errorf("tc[%d] no yaml marshaling error expected got: %s", i, err.Error())
+		} else {
+			var un semver.Pattern
+			if err := yaml.Unmarshal(b, &un); err != nil {
+				t.Errorf("tc[%d] no yaml unmarshaling error expected got: %s", i, err.Error())
+			} else if un != p {
+				t.Errorf("tc[%d] yaml unmarshaling mismatch expected %s got: %s from %s", i, p, un, string(b))
+			}
+		}
+
+		if b, err := p.MarshalText(); err != nil {
+			t.Errorf("tc[%d] no text marshaling error expected got: %s", i, err.Error())
+		} else {
+			var un semver.Pattern
+			if err := un.UnmarshalText(b); err != nil {
+				t.Errorf("tc[%d] no text unmarshaling error expected got: %s", i, err.Error())
+			} else if un != p {
+				t.Errorf("tc[%d] text unmarshaling mismatch expected %s got: %s from %s", i, p, un, string(b))
+			}
+		}
+	}
+}
+
+func TestPatternError(t *testing.T) {
+	var tcs = []struct {
+		input string
+		err   error
+	}{
+		{"", semver.ErrorEmpty{}},
+		{"1.x.3", semver.ErrorInvalidCharacter{}},
+		{"1.xx", semver.ErrorInvalidCharacter{}},
+		{"1..3", semver.ErrorInvalidCharacter{}},
+		{"1.2.", semver.ErrorInvalidCharacter{}},
+		{"1.2.3.4", semver.ErrorInvalidCharacter{}},
+		{"a.b", semver.ErrorInvalidCharacter{}},
+		{"65536.x", semver.ErrorMajorTooBig("65536.x")},
+		{"1.256", semver.ErrorMinorTooBig("1.256")},
+		{"1.2.256", semver.ErrorPatchTooBig("1.2.256")},
+	}
+
+	for i, tc := range tcs {
+		if _, err := semver.ParsePattern(tc.input); err == nil {
+			t.Errorf("tc[%d] pattern parsing error expected, got: nil", i)
+		} else if e := err.(*semver.Error).Unwrap(); fmt.Sprintf("%T", e) != fmt.Sprintf("%T", tc.err) {
+			t.Errorf("tc[%d] pattern parsing error mismatch expected: %T got: %T", i, tc.err, e)
+		}
+	}
+}
+
+func ExampleParsePattern() {
+	p, err := semver.ParsePattern("2.x")
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(p.Match(semver.NewNumber(2, 7, 1)), p.Match(semver.NewNumber(3, 0, 0)))
+	}
+	// Output: true false
+}
+
+func ExamplePattern_Range() {
+	p, _ := semver.ParsePattern("1.2.*")
+
+	fmt.Println(p.Range())
+	// Output: 1.2.0 - 1.2.255
+}
@@ -0,0 +1,336 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package pkgver converts semver.Number values to and from the versions of
+// Debian (epoch:upstream-revision) and RPM (epoch:version-release) packages,
+// and compares those versions the way dpkg and rpm do, so that the order of
+// generated package versions can be checked against that of the Numbers.
+package pkgver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// ErrInvalid is the error produced for malformed package versions.
+var ErrInvalid = errors.New("pkgver: invalid package version")
+
+// Debian is the version of a Debian package.
+type Debian struct {
+	Epoch    uint
+	Upstream string
+	Revision string
+}
+
+// NewDebian returns the Debian version of the Number.
+func NewDebian(n semver.Number, epoch uint, revision string) Debian {
+	return Debian{epoch, n.GoString(), revision}
+}
+
+// ParseDebian parses the Debian version s.
+func ParseDebian(s string) (Debian, error) {
+	var d Debian
+	rest := s
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := parseEpoch(rest[:i])
+		if err != nil {
+			return Debian{}, fmt.Errorf("%w %q: %s", ErrInvalid, s, err)
+		}
+		d.Epoch, rest = epoch, rest[i+1:]
+	}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		d.Revision, rest = rest[i+1:], rest[:i]
+		if d.Revision == "" || !validChars(d.Revision, "+.~") {
+			return Debian{}, fmt.Errorf("%w %q: malformed revision", ErrInvalid, s)
+		}
+	}
+	if rest == "" || !isDigit(rest[0]) || !validChars(rest, "+.~-:") {
+		return Debian{}, fmt.Errorf("%w %q: malformed upstream version", ErrInvalid, s)
+	}
+	d.Upstream = rest
+	return d, nil
+}
+
+// String satisfies the fmt.Stringer interface.
+func (d Debian) String() string {
+	s := d.Upstream
+	if d.Epoch > 0 {
+		s = strconv.FormatUint(uint64(d.Epoch), 10) + ":" + s
+	}
+	if d.Revision != "" {
+		s += "-" + d.Revision
+	}
+	return s
+}
+
+// Number returns the Number of the upstream version.
+func (d Debian) Number() (semver.Number, error) { return semver.ParseNumber(d.Upstream) }
+
+// CompareDebian compares the Debian versions as dpkg does,
+// returning -1 if a is lower than b, 1 if it is greater or 0 otherwise.
+func CompareDebian(a, b Debian) int {
+	switch {
+	case a.Epoch < b.Epoch:
+		return -1
+	case a.Epoch > b.Epoch:
+		return 1
+	}
+	if c := verrevcmp(a.Upstream, b.Upstream); c != 0 {
+		return c
+	}
+	return verrevcmp(a.Revision, b.Revision)
+}
+
+// RPM is the version of an RPM package.
+type RPM struct {
+	Epoch   uint
+	Version string
+	Release string
+}
+
+// NewRPM returns the RPM version of the Number.
+func NewRPM(n semver.Number, epoch uint, release string) RPM {
+	return RPM{epoch, n.GoString(), release}
+}
+
+// ParseRPM parses the RPM version s.
+func ParseRPM(s string) (RPM, error) {
+	var r RPM
+	rest := s
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := parseEpoch(rest[:i])
+		if err != nil {
+			return RPM{}, fmt.Errorf("%w %q: %s", ErrInvalid, s, err)
+		}
+		r.Epoch, rest = epoch, rest[i+1:]
+	}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		r.Release, rest = rest[i+1:], rest[:i]
+		if r.Release == "" || !validChars(r.Release, "._+~^") {
+			return RPM{}, fmt.Errorf("%w %q: malformed release", ErrInvalid, s)
+		}
+	}
+	if rest == "" || !validChars(rest, "._+~^") {
+		return RPM{}, fmt.Errorf("%w %q: malformed version", ErrInvalid, s)
+	}
+	r.Version = rest
+	return r, nil
+}
+
+// String satisfies the fmt.Stringer interface.
+func (r RPM) String() string {
+	s := r.Version
+	if r.Epoch > 0 {
+		s = strconv.FormatUint(uint64(r.Epoch), 10) + ":" + s
+	}
+	if r.Release != "" {
+		s += "-" + r.Release
+	}
+	return s
+}
+
+// Number returns the Number of the version.
+func (r RPM) Number() (semver.Number, error) { return semver.ParseNumber(r.Version) }
+
+// CompareRPM compares the RPM versions as rpm does,
+// returning -1 if a is lower than b, 1 if it is greater or 0 otherwise.
+// Releases are only compared when both versions have one.
+func CompareRPM(a, b RPM) int {
+	switch {
+	case a.Epoch < b.Epoch:
+		return -1
+	case a.Epoch > b.Epoch:
+		return 1
+	}
+	if c := rpmvercmp(a.Version, b.Version); c != 0 || a.Release == "" || b.Release == "" {
+		return c
+	}
+	return rpmvercmp(a.Release, b.Release)
+}
+
+func parseEpoch(s string) (uint, error) {
+	epoch, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, errors.New("malformed epoch")
+	}
+	return uint(epoch), nil
+}
+
+// validChars tells whether s holds only alphanumeric characters
+// and those in extra.
+func validChars(s, extra string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) && !isLetter(s[i]) && strings.IndexByte(extra, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+// at returns the byte at i, or 0 past the end of s.
+func at(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
+
+// order is the weight of a non digit character in dpkg comparisons:
+// '~' sorts before anything, even the end of the string,
+// and letters sort before any other character.
+func order(c byte) int {
+	switch {
+	case isDigit(c):
+		return 0
+	case isLetter(c):
+		return int(c)
+	case c == '~':
+		return -1
+	case c != 0:
+		return int(c) + 256
+	}
+	return 0
+}
+
+// verrevcmp is the comparison dpkg applies to upstream versions and
+// revisions: alternating non digit parts, compared by order,
+// and digit parts, compared numerically.
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			if ac, bc := order(at(a, i)), order(at(b, j)); ac != bc {
+				return sign(ac - bc)
+			}
+			i, j = i+1, j+1
+		}
+		for at(a, i) == '0' {
+			i++
+		}
+		for at(b, j) == '0' {
+			j++
+		}
+
+		diff := 0
+		for isDigit(at(a, i)) && isDigit(at(b, j)) {
+			if diff == 0 {
+				diff = int(a[i]) - int(b[j])
+			}
+			i, j = i+1, j+1
+		}
+		switch {
+		case isDigit(at(a, i)):
+			return 1
+		case isDigit(at(b, j)):
+			return -1
+		case diff != 0:
+			return sign(diff)
+		}
+	}
+	return 0
+}
+
+// rpmvercmp is the comparison rpm applies to versions and releases:
+// alphanumeric segments, compared numerically when made of digits,
+// which are newer than those made of letters. A '~' sorts before
+// anything and a '^' after the end of the string but before anything else.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) && !isLetter(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isDigit(b[j]) && !isLetter(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+
+		if ac, bc := at(a, i), at(b, j); ac == '~' || bc == '~' {
+			if ac != '~' {
+				return 1
+			}
+			if bc != '~' {
+				return -1
+			}
+			i, j = i+1, j+1
+			continue
+		} else if ac == '^' || bc == '^' {
+			switch {
+			case ac == 0:
+				return -1
+			case bc == 0:
+				return 1
+			case ac != '^':
+				return 1
+			case bc != '^':
+				return -1
+			}
+			i, j = i+1, j+1
+			continue
+		}
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		numeric := isDigit(a[i])
+		in := isLetter
+		if numeric {
+			in = isDigit
+		}
+		si, sj := i, j
+		for i < len(a) && in(a[i]) {
+			i++
+		}
+		for j < len(b) && in(b[j]) {
+			j++
+		}
+		if sj == j {
+			// segments of different types: numbers are newer.
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		sa, sb := a[si:i], b[sj:j]
+		if numeric {
+			sa, sb = strings.TrimLeft(sa, "0"), strings.TrimLeft(sb, "0")
+			if len(sa) != len(sb) {
+				return sign(len(sa) - len(sb))
+			}
+		}
+		if c := strings.Compare(sa, sb); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i < len(a):
+		return 1
+	}
+	return -1
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
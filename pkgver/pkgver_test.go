@@ -0,0 +1,175 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package pkgver_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/pkgver"
+)
+
+func TestParseDebian(t *testing.T) {
+	var tcs = []struct {
+		s   string
+		exp pkgver.Debian
+		err bool
+	}{
+		{"1.2.3", pkgver.Debian{Upstream: "1.2.3"}, false},
+		{"2:1.2.3-1ubuntu0.1", pkgver.Debian{Epoch: 2, Upstream: "1.2.3", Revision: "1ubuntu0.1"}, false},
+		{"1.2.3-rc1-2", pkgver.Debian{Upstream: "1.2.3-rc1", Revision: "2"}, false},
+		{"1:2:3-1", pkgver.Debian{Epoch: 1, Upstream: "2:3", Revision: "1"}, false},
+		{"a1.2", pkgver.Debian{}, true},
+		{"x:1.2", pkgver.Debian{}, true},
+		{"1.2-", pkgver.Debian{}, true},
+		{"1.2_3", pkgver.Debian{}, true},
+		{"", pkgver.Debian{}, true},
+	}
+
+	for i, tc := range tcs {
+		d, err := pkgver.ParseDebian(tc.s)
+		if (err != nil) != tc.err || err != nil && !errors.Is(err, pkgver.ErrInvalid) {
+			t.Errorf("tc[%d] %q error mismatch expected: %t got: %v", i, tc.s, tc.err, err)
+			continue
+		}
+		if d != tc.exp {
+			t.Errorf("tc[%d] %q mismatch expected: %+v got: %+v", i, tc.s, tc.exp, d)
+		}
+		if err == nil && d.String() != tc.s {
+			t.Errorf("tc[%d] string mismatch expected: %s got: %s", i, tc.s, d.String())
+		}
+	}
+}
+
+func TestParseRPM(t *testing.T) {
+	var tcs = []struct {
+		s   string
+		exp pkgver.RPM
+		err bool
+	}{
+		{"1.2.3", pkgver.RPM{Version: "1.2.3"}, false},
+		{"3:1.2.3-4.el8", pkgver.RPM{Epoch: 3, Version: "1.2.3", Release: "4.el8"}, false},
+		{"1.2.3~rc1^git2-1", pkgver.RPM{Version: "1.2.3~rc1^git2", Release: "1"}, false},
+		{"1.2-3-4", pkgver.RPM{}, true},
+		{"1.2:3", pkgver.RPM{}, true},
+		{"-1", pkgver.RPM{}, true},
+	}
+
+	for i, tc := range tcs {
+		r, err := pkgver.ParseRPM(tc.s)
+		if (err != nil) != tc.err {
+			t.Errorf("tc[%d] %q error mismatch expected: %t got: %v", i, tc.s, tc.err, err)
+			continue
+		}
+		if r != tc.exp {
+			t.Errorf("tc[%d] %q mismatch expected: %+v got: %+v", i, tc.s, tc.exp, r)
+		}
+		if err == nil && r.String() != tc.s {
+			t.Errorf("tc[%d] string mismatch expected: %s got: %s", i, tc.s, r.String())
+		}
+	}
+}
+
+func TestCompareDebian(t *testing.T) {
+	var tcs = []struct {
+		a, b string
+		exp  int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.0-0", 0},
+		{"1.01", "1.1", 0},
+		{"1.9", "1.10", -1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc1~1", 1},
+		{"1.0", "1.0+b1", -1},
+		{"1.0", "1.0a", -1},
+		{"1.0a", "1.0+", -1},
+		{"1.0.0", "1.0", 1},
+		{"1:0.1", "2.0", 1},
+		{"2.0-1", "2.0-2", -1},
+		{"2.0-1ubuntu1", "2.0-1", 1},
+		{"2.0-10", "2.0-9", 1},
+	}
+
+	for i, tc := range tcs {
+		a, _ := pkgver.ParseDebian(tc.a)
+		b, _ := pkgver.ParseDebian(tc.b)
+		if c := pkgver.CompareDebian(a, b); c != tc.exp {
+			t.Errorf("tc[%d] %s <=> %s mismatch expected: %d got: %d", i, tc.a, tc.b, tc.exp, c)
+		}
+		if c := pkgver.CompareDebian(b, a); c != -tc.exp {
+			t.Errorf("tc[%d] %s <=> %s mismatch expected: %d got: %d", i, tc.b, tc.a, -tc.exp, c)
+		}
+	}
+}
+
+func TestCompareRPM(t *testing.T) {
+	var tcs = []struct {
+		a, b string
+		exp  int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.01", "1.1", 0},
+		{"1.9", "1.10", -1},
+		{"1.0", "1.0.0", -1},
+		{"1.0a", "1.0", 1},
+		{"1.0a", "1.0.1", -1},
+		{"1.a", "1.1", -1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.0.1", -1},
+		{"1.0^git1", "1.0~rc1", 1},
+		{"1.0_1", "1.0.1", 0},
+		{"1:0.1", "2.0", 1},
+		{"2.0-1.el8", "2.0-1.el9", -1},
+		{"2.0-1", "2.0", 0},
+	}
+
+	for i, tc := range tcs {
+		a, _ := pkgver.ParseRPM(tc.a)
+		b, _ := pkgver.ParseRPM(tc.b)
+		if c := pkgver.CompareRPM(a, b); c != tc.exp {
+			t.Errorf("tc[%d] %s <=> %s mismatch expected: %d got: %d", i, tc.a, tc.b, tc.exp, c)
+		}
+		if c := pkgver.CompareRPM(b, a); c != -tc.exp {
+			t.Errorf("tc[%d] %s <=> %s mismatch expected: %d got: %d", i, tc.b, tc.a, -tc.exp, c)
+		}
+	}
+}
+
+func TestSameOrder(t *testing.T) {
+	ns := semver.Numbers{
+		semver.NewNumber(10, 0, 0), semver.NewNumber(1, 10, 0), semver.NewNumber(1, 9, 255),
+		semver.NewNumber(0, 0, 1), semver.NewNumber(2, 0, 0), semver.NewNumber(1, 2, 3),
+		semver.NewNumber(1, 2, 30), semver.NewNumber(0, 10, 0), semver.NewNumber(0, 0, 0),
+	}
+	sort.Sort(ns)
+
+	for i := 1; i < len(ns); i++ {
+		a, b := ns[i-1], ns[i]
+		if c := pkgver.CompareDebian(pkgver.NewDebian(a, 0, "1"), pkgver.NewDebian(b, 0, "1")); c != -1 {
+			t.Errorf("debian %#v <=> %#v mismatch expected: -1 got: %d", a, b, c)
+		}
+		if c := pkgver.CompareRPM(pkgver.NewRPM(a, 0, "1"), pkgver.NewRPM(b, 0, "1")); c != -1 {
+			t.Errorf("rpm %#v <=> %#v mismatch expected: -1 got: %d", a, b, c)
+		}
+	}
+}
+
+func ExampleNewDebian() {
+	d := pkgver.NewDebian(semver.NewNumber(1, 4, 0), 1, "2")
+	fmt.Println(d)
+
+	n, _ := d.Number()
+	fmt.Println(n.GoString())
+	// Output:
+	// 1:1.4.0-2
+	// 1.4.0
+}
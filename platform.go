@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FileVersion is a Windows file version, as found in the dwFileVersionMS
+// and dwFileVersionLS fields of VS_FIXEDFILEINFO: four 16 bit parts,
+// major, minor, patch and build, packed into two 32 bit values.
+type FileVersion struct{ MS, LS uint32 }
+
+// FileVersion returns the Windows FileVersion of the Number for the build.
+func (n Number) FileVersion(build uint16) FileVersion {
+	return FileVersion{
+		MS: uint32(n.Major())<<16 | uint32(n.Minor()),
+		LS: uint32(n.Patch())<<16 | uint32(build),
+	}
+}
+
+// Parts returns the four parts of the FileVersion.
+func (f FileVersion) Parts() (major, minor, patch, build uint16) {
+	return uint16(f.MS >> 16), uint16(f.MS), uint16(f.LS >> 16), uint16(f.LS)
+}
+
+// String satisfies the fmt.Stringer interface.
+func (f FileVersion) String() string {
+	major, minor, patch, build := f.Parts()
+	return fmt.Sprintf("%d.%d.%d.%d", major, minor, patch, build)
+}
+
+// Number returns the Number of the FileVersion, dropping its build part.
+func (f FileVersion) Number() (Number, error) {
+	major, minor, patch, _ := f.Parts()
+	switch {
+	case minor > 0xFF:
+		return 0, &Error{ErrorMinorTooBig(strconv.Itoa(int(minor)))}
+	case patch > 0xFF:
+		return 0, &Error{ErrorPatchTooBig(strconv.Itoa(int(patch)))}
+	}
+	return NewNumber(major, byte(minor), byte(patch)), nil
+}
+
+// MaxVersionCode is the greatest Android versionCode Google Play accepts.
+const MaxVersionCode = 2100000000
+
+// VersionCodeScheme encodes Numbers as Android versionCode integers, and
+// CFBundleVersion build numbers, by adding up each component times its
+// multiplier, e.g. major*10000 + minor*100 + patch.
+//
+// Each multiplier must be greater than the following one. Components are
+// bounded by the multiplier preceding them, so that a greater Number always
+// gets a greater code: with the scheme above minor and patch can't exceed 99.
+type VersionCodeScheme struct{ Major, Minor, Patch int }
+
+// DefaultVersionCodeScheme encodes 1.2.3 as 10203.
+var DefaultVersionCodeScheme = VersionCodeScheme{10000, 100, 1}
+
+// VersionCode returns the Android versionCode of the Number.
+func (s VersionCodeScheme) VersionCode(n Number) (int, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+
+	major, minor, patch := int64(n.Major()), int64(n.Minor()), int64(n.Patch())
+	low := minor*int64(s.Minor) + patch*int64(s.Patch)
+	code := major*int64(s.Major) + low
+	if patch*int64(s.Patch) >= int64(s.Minor) || low >= int64(s.Major) || code > MaxVersionCode {
+		return 0, &Error{ErrorOverflow{n, "versionCode"}}
+	}
+	return int(code), nil
+}
+
+// Number returns the Number encoded by the Android versionCode.
+func (s VersionCodeScheme) Number(code int) (Number, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	if code < 0 || code > MaxVersionCode || code%s.Minor%s.Patch != 0 {
+		return 0, &Error{ErrorInvalidVersionCode(code)}
+	}
+
+	major, minor, patch := code/s.Major, code%s.Major/s.Minor, code%s.Minor/s.Patch
+	switch {
+	case major > 0xFFFF:
+		return 0, &Error{ErrorMajorTooBig(strconv.Itoa(major))}
+	case minor > 0xFF:
+		return 0, &Error{ErrorMinorTooBig(strconv.Itoa(minor))}
+	case patch > 0xFF:
+		return 0, &Error{ErrorPatchTooBig(strconv.Itoa(patch))}
+	}
+	return NewNumber(uint16(major), byte(minor), byte(patch)), nil
+}
+
+// BundleVersion returns the Apple CFBundleVersion build number of the
+// Number: its versionCode, which increases along with the Number.
+func (s VersionCodeScheme) BundleVersion(n Number) (string, error) {
+	code, err := s.VersionCode(n)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(code), nil
+}
+
+func (s VersionCodeScheme) validate() error {
+	if s.Patch < 1 || s.Minor <= s.Patch || s.Major <= s.Minor {
+		return &Error{ErrorVersionCodeScheme(fmt.Sprintf("%d, %d, %d", s.Major, s.Minor, s.Patch))}
+	}
+	return nil
+}
+
+// BundleShortVersion returns the Apple CFBundleShortVersionString of the
+// Number: its three components separated by periods, e.g. "1.2.0".
+func (n Number) BundleShortVersion() string { return n.GoString() }
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestFileVersion(t *testing.T) {
+	n := semver.NewNumber(513, 2, 3)
+	f := n.FileVersion(4000)
+	if exp := (semver.FileVersion{MS: 513<<16 | 2, LS: 3<<16 | 4000}); f != exp {
+		t.Errorf("FileVersion mismatch expected: %#v got: %#v", exp, f)
+	}
+	if f.String() != "513.2.3.4000" {
+		t.Errorf("FileVersion string mismatch expected: %s got: %s", "513.2.3.4000", f.String())
+	}
+	if got, err := f.Number(); err != nil || got != n {
+		t.Errorf("Number mismatch expected: %#v got: %#v (%v)", n, got, err)
+	}
+
+	var (
+		mtb semver.ErrorMinorTooBig
+		ptb semver.ErrorPatchTooBig
+	)
+	if _, err := (semver.FileVersion{MS: 1<<16 | 256}).Number(); !errors.As(err, &mtb) || mtb != "256" {
+		t.Errorf("minor error mismatch expected: %v got: %v", semver.ErrorMinorTooBig("256"), err)
+	}
+	if _, err := (semver.FileVersion{MS: 1 << 16, LS: 300 << 16}).Number(); !errors.As(err, &ptb) || ptb != "300" {
+		t.Errorf("patch error mismatch expected: %v got: %v", semver.ErrorPatchTooBig("300"), err)
+	}
+}
+
+func TestVersionCode(t *testing.T) {
+	wide := semver.VersionCodeScheme{Major: 1000000, Minor: 1000, Patch: 1}
+	var tcs = []struct {
+		scheme semver.VersionCodeScheme
+		n      semver.Number
+		code   int
+		err    error
+	}{
+		{semver.DefaultVersionCodeScheme, semver.NewNumber(1, 2, 3), 10203, nil},
+		{semver.DefaultVersionCodeScheme, semver.NewNumber(0, 99, 99), 9999, nil},
+		{semver.DefaultVersionCodeScheme, semver.NewNumber(1, 2, 100), 0, semver.ErrorOverflow{}},
+		{semver.DefaultVersionCodeScheme, semver.NewNumber(1, 100, 0), 0, semver.ErrorOverflow{}},
+		{wide, semver.NewNumber(2100, 0, 0), 2100000000, nil},
+		{wide, semver.NewNumber(2100, 0, 1), 0, semver.ErrorOverflow{}},
+		{wide, semver.NewNumber(3, 255, 255), 3255255, nil},
+		{semver.VersionCodeScheme{Major: 100, Minor: 100, Patch: 1}, semver.NewNumber(1, 0, 0), 0, semver.ErrorVersionCodeScheme("")},
+		{semver.VersionCodeScheme{}, semver.NewNumber(1, 0, 0), 0, semver.ErrorVersionCodeScheme("")},
+	}
+
+	for i, tc := range tcs {
+		code, err := tc.scheme.VersionCode(tc.n)
+		if !sameErrorType(err, tc.err) {
+			t.Errorf("tc[%d] %#v error mismatch expected: %T got: %v", i, tc.n, tc.err, err)
+			continue
+		}
+		if code != tc.code {
+			t.Errorf("tc[%d] %#v versionCode mismatch expected: %d got: %d", i, tc.n, tc.code, code)
+		}
+		if err != nil {
+			continue
+		}
+		if n, err := tc.scheme.Number(code); err != nil || n != tc.n {
+			t.Errorf("tc[%d] Number mismatch expected: %#v got: %#v (%v)", i, tc.n, n, err)
+		}
+	}
+
+	var ivc semver.ErrorInvalidVersionCode
+	for _, code := range []int{-1, 2100000001} {
+		if _, err := semver.DefaultVersionCodeScheme.Number(code); !errors.As(err, &ivc) {
+			t.Errorf("%d error mismatch expected: %T got: %v", code, ivc, err)
+		}
+	}
+	if _, err := (semver.VersionCodeScheme{Major: 1000, Minor: 10, Patch: 2}).Number(1235); !errors.As(err, &ivc) {
+		t.Errorf("1235 error mismatch expected: %T got: %v", ivc, err)
+	}
+	var Mtb semver.ErrorMajorTooBig
+	if _, err := semver.DefaultVersionCodeScheme.Number(700000000); !errors.As(err, &Mtb) {
+		t.Errorf("700000000 error mismatch expected: %T got: %v", Mtb, err)
+	}
+}
+
+func TestVersionCodeMonotonic(t *testing.T) {
+	ns := semver.Numbers{
+		semver.NewNumber(0, 0, 1), semver.NewNumber(0, 1, 0), semver.NewNumber(0, 99, 99),
+		semver.NewNumber(1, 0, 0), semver.NewNumber(1, 0, 99), semver.NewNumber(1, 1, 0), semver.NewNumber(2, 0, 0),
+	}
+	prev := -1
+	for _, n := range ns {
+		code, err := semver.DefaultVersionCodeScheme.VersionCode(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code <= prev {
+			t.Errorf("versionCode of %#v is not greater than the previous one: %d <= %d", n, code, prev)
+		}
+		prev = code
+	}
+}
+
+func sameErrorType(err, target error) bool {
+	switch target.(type) {
+	case nil:
+		return err == nil
+	case semver.ErrorOverflow:
+		var e semver.ErrorOverflow
+		return errors.As(err, &e)
+	case semver.ErrorVersionCodeScheme:
+		var e semver.ErrorVersionCodeScheme
+		return errors.As(err, &e)
+	}
+	return false
+}
+
+func ExampleVersionCodeScheme() {
+	n := semver.NewNumber(1, 4, 2)
+	code, _ := semver.DefaultVersionCodeScheme.VersionCode(n)
+	build, _ := semver.DefaultVersionCodeScheme.BundleVersion(n)
+	fmt.Println(code, n.BundleShortVersion(), build)
+
+	_, err := semver.DefaultVersionCodeScheme.VersionCode(semver.NewNumber(1, 4, 120))
+	fmt.Println(err)
+	// Output:
+	// 10402 1.4.2 10402
+	// semver: "1.4.120" overflows versionCode
+}
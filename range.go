@@ -0,0 +1,28 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import "strings"
+
+// Range represents every Number from Min to Max, both inclusive.
+//
+// A Range whose Min is greater than its Max is empty.
+type Range struct{ Min, Max Number }
+
+// Contains reports whether n is within the Range.
+func (r Range) Contains(n Number) bool { return r.Min <= n && n <= r.Max }
+
+// IsEmpty reports whether the Range contains no Numbers at all.
+func (r Range) IsEmpty() bool { return r.Min > r.Max }
+
+// String satisfies the fmt.Stringer interface.
+func (r Range) String() string {
+	var b strings.Builder
+	b.WriteString(r.Min.GoString())
+	b.WriteString(" - ")
+	b.WriteString(r.Max.GoString())
+	return b.String()
+}
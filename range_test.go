@@ -0,0 +1,59 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestRange(t *testing.T) {
+	var tcs = []struct {
+		r       semver.Range
+		in, out []semver.Number
+		empty   bool
+		str     string
+	}{
+		{
+			semver.Range{Min: semver.NewNumber(1, 0, 0), Max: semver.NewNumber(1, 255, 255)},
+			[]semver.Number{semver.NewNumber(1, 0, 0), semver.NewNumber(1, 2, 3), semver.NewNumber(1, 255, 255)},
+			[]semver.Number{semver.NewNumber(0, 255, 255), semver.NewNumber(2, 0, 0)},
+			false, "1.0.0 - 1.255.255",
+		},
+		{
+			semver.Range{Min: semver.NewNumber(1, 2, 3), Max: semver.NewNumber(1, 2, 3)},
+			[]semver.Number{semver.NewNumber(1, 2, 3)},
+			[]semver.Number{semver.NewNumber(1, 2, 2), semver.NewNumber(1, 2, 4)},
+			false, "1.2.3 - 1.2.3",
+		},
+		{
+			semver.Range{Min: semver.NewNumber(2, 0, 0), Max: semver.NewNumber(1, 0, 0)},
+			nil,
+			[]semver.Number{semver.NewNumber(1, 0, 0), semver.NewNumber(1, 5, 0), semver.NewNumber(2, 0, 0)},
+			true, "2.0.0 - 1.0.0",
+		},
+	}
+
+	for i, tc := range tcs {
+		if e := tc.r.IsEmpty(); e != tc.empty {
+			t.Errorf("tc[%d] is empty mismatch expected: %t got: %t", i, tc.empty, e)
+		}
+		if s := tc.r.String(); s != tc.str {
+			t.Errorf("tc[%d] string mismatch expected: %s got: %s", i, tc.str, s)
+		}
+		for _, n := range tc.in {
+			if !tc.r.Contains(n) {
+				t.Errorf("tc[%d] expected %s to contain %s", i, tc.r, n)
+			}
+		}
+		for _, n := range tc.out {
+			if tc.r.Contains(n) {
+				t.Errorf("tc[%d] expected %s NOT to contain %s", i, tc.r, n)
+			}
+		}
+	}
+}
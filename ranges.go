@@ -0,0 +1,382 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ranges represents the union of several Range values,
+// which is how version constraints such as "^1.2 || >=3" are represented.
+//
+// Ranges produced by this package are normalized: sorted in ascending
+// order, with no empty, overlapping or adjacent Range values.
+type Ranges []Range
+
+// NewRanges returns the normalized union of the specified Range values.
+func NewRanges(rs ...Range) Ranges { return Ranges(rs).normalize() }
+
+// Contains reports whether n is within any of the Ranges.
+func (rs Ranges) Contains(n Number) bool {
+	for _, r := range rs {
+		if r.Contains(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether the Ranges contain no Numbers at all.
+func (rs Ranges) IsEmpty() bool {
+	for _, r := range rs {
+		if !r.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Max returns the greatest Number within the Ranges.
+// It returns false if the Ranges are empty.
+func (rs Ranges) Max() (Number, bool) {
+	var (
+		max Number
+		ok  bool
+	)
+	for _, r := range rs {
+		if !r.IsEmpty() && (!ok || r.Max > max) {
+			max, ok = r.Max, true
+		}
+	}
+	return max, ok
+}
+
+// Union returns the Numbers that are within either rs or o.
+func (rs Ranges) Union(o Ranges) Ranges {
+	u := make(Ranges, 0, len(rs)+len(o))
+	return append(append(u, rs...), o...).normalize()
+}
+
+// Intersect returns the Numbers that are within both rs and o.
+func (rs Ranges) Intersect(o Ranges) Ranges {
+	var is Ranges
+	for _, a := range rs {
+		for _, b := range o {
+			is = append(is, Range{maxNumber(a.Min, b.Min), minNumber(a.Max, b.Max)})
+		}
+	}
+	return is.normalize()
+}
+
+// Complement returns the Numbers that are not within the Ranges.
+func (rs Ranges) Complement() Ranges {
+	var (
+		c    Ranges
+		next Number
+		done bool
+	)
+	for _, r := range rs.normalize() {
+		if r.Min > next {
+			c = append(c, Range{next, r.Min - 1})
+		}
+		if r.Max == MaxNumber {
+			done = true
+			break
+		}
+		next = r.Max + 1
+	}
+	if !done {
+		c = append(c, Range{next, MaxNumber})
+	}
+	return c
+}
+
+// String satisfies the fmt.Stringer interface.
+func (rs Ranges) String() string {
+	var b strings.Builder
+	rs.write(&b)
+	return b.String()
+}
+
+// MarshalYAML satisfies the gopkg.in/yaml.v3.Marshaler interface.
+func (rs Ranges) MarshalYAML() (interface{}, error) {
+	return rs.String(), nil
+}
+
+// MarshalJSON satisfies the encoding/json.Marshaler interface.
+func (rs Ranges) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('"')
+	rs.write(&b)
+	b.WriteByte('"')
+	return b.Bytes(), nil
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (rs Ranges) MarshalText() ([]byte, error) {
+	var b bytes.Buffer
+	rs.write(&b)
+	return b.Bytes(), nil
+}
+
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.
+// The Ranges are encoded as their count, as 2 bytes,
+// followed by the Min and Max of each Range, as 4 bytes each,
+// all of them in big-endian order.
+func (rs Ranges) MarshalBinary() ([]byte, error) {
+	if len(rs) > 65535 {
+		return nil, &Error{ErrorInvalidLength(len(rs))}
+	}
+	b := make([]byte, 2+8*len(rs))
+	binary.BigEndian.PutUint16(b, uint16(len(rs)))
+	for i, r := range rs {
+		binary.BigEndian.PutUint32(b[2+8*i:], uint32(r.Min))
+		binary.BigEndian.PutUint32(b[6+8*i:], uint32(r.Max))
+	}
+	return b, nil
+}
+
+// UnmarshalYAML satisfies the gopkg.in/yaml.v3.Unmarshaler interface.
+func (rs *Ranges) UnmarshalYAML(value *yaml.Node) error {
+	r, err := ParseRanges(value.Value)
+	if err != nil {
+		return err
+	}
+	*rs = r
+	return nil
+}
+
+// UnmarshalJSON satisfies the encoding/json.Unmarshaler interface.
+// The string may hold escape sequences, as encoding/json
+// writes "<" as "\u003c" when marshaling empty Ranges.
+func (rs *Ranges) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return rs.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (rs *Ranges) UnmarshalText(text []byte) error {
+	r, err := ParseRanges(string(text))
+	if err != nil {
+		return err
+	}
+	*rs = r
+	return nil
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.
+func (rs *Ranges) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return &Error{ErrorInvalidLength(len(data))}
+	}
+	l := int(binary.BigEndian.Uint16(data))
+	if len(data) != 2+8*l {
+		return &Error{ErrorInvalidLength(len(data))}
+	}
+	r := make(Ranges, l)
+	for i := range r {
+		r[i].Min = Number(binary.BigEndian.Uint32(data[2+8*i:]))
+		r[i].Max = Number(binary.BigEndian.Uint32(data[6+8*i:]))
+	}
+	*rs = r.normalize()
+	return nil
+}
+
+// noRanges is how empty Ranges are written, as it parses back to them.
+const noRanges = "<0.0.0"
+
+func (rs Ranges) write(b interface {
+	WriteString(string) (n int, err error)
+}) {
+	if len(rs) == 0 {
+		b.WriteString(noRanges)
+		return
+	}
+	for i, r := range rs {
+		if i > 0 {
+			b.WriteString(" || ")
+		}
+		b.WriteString(r.String())
+	}
+}
+
+// normalize sorts the Ranges, drops the empty ones and
+// merges those that overlap or are adjacent.
+func (rs Ranges) normalize() Ranges {
+	var s Ranges
+	for _, r := range rs {
+		if !r.IsEmpty() {
+			s = append(s, r)
+		}
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Min < s[j].Min })
+
+	var n Ranges
+	for _, r := range s {
+		if l := len(n) - 1; l >= 0 && (n[l].Max == MaxNumber || r.Min <= n[l].Max+1) {
+			n[l].Max = maxNumber(n[l].Max, r.Max)
+		} else {
+			n = append(n, r)
+		}
+	}
+	return n
+}
+
+// ParseRanges takes a constraint string, parses it and
+// returns the Ranges it represents if parsing was successful.
+//
+// Constraints are sets of comparators separated by "||"; within a set,
+// comparators are separated by spaces or commas and must all be satisfied.
+// The supported comparators are:
+//
+//	1.2.3, =1.2.3   exactly 1.2.3
+//	1.2, 1.2.x      any 1.2 Number, like every other wildcard Pattern
+//	>1.2, >=1.2     greater than 1.2.x, greater than or equal to 1.2.0
+//	<1.2, <=1.2     lower than 1.2.0, lower than or equal to 1.2.x
+//	!=1.2           any Number but 1.2.x
+//	~1.2.3          at least 1.2.3 but lower than 1.3.0
+//	^1.2.3          at least 1.2.3 but lower than 2.0.0;
+//	                ^0.2.3 stops at 0.3.0 and ^0.0.3 means exactly 0.0.3
+//	1.2 - 2.3       at least 1.2.0 and at most 2.3.x
+//
+// Empty Ranges, which contain no Number, are written as "<0.0.0".
+func ParseRanges(s string) (Ranges, error) {
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil, errorEmpty
+	}
+
+	var union Ranges
+	for _, set := range strings.Split(s, "||") {
+		tokens := strings.FieldsFunc(set, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(tokens) == 0 {
+			return nil, errorEmpty
+		}
+
+		is := Ranges{{0, MaxNumber}}
+		for i := 0; i < len(tokens); i++ {
+			var (
+				rs  Ranges
+				err error
+			)
+			switch {
+			case i+2 < len(tokens) && tokens[i+1] == "-":
+				rs, err = parseHyphen(tokens[i], tokens[i+2])
+				i += 2
+			case i+1 < len(tokens) && isOperator(tokens[i]):
+				rs, err = parseComparator(tokens[i] + tokens[i+1])
+				i++
+			default:
+				rs, err = parseComparator(tokens[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			is = is.Intersect(rs)
+		}
+		union = append(union, is...)
+	}
+
+	return union.normalize(), nil
+}
+
+func parseHyphen(from, to string) (Ranges, error) {
+	f, err := ParsePattern(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ParsePattern(to)
+	if err != nil {
+		return nil, err
+	}
+	return NewRanges(Range{f.Range().Min, t.Range().Max}), nil
+}
+
+func parseComparator(s string) (Ranges, error) {
+	i := 0
+	for i < len(s) && strings.IndexByte("<>=!~^", s[i]) >= 0 {
+		i++
+	}
+	op := s[:i]
+	if !isOperator(op) && op != "" {
+		return nil, &Error{ErrorInvalidCharacter{s, s[i-1]}}
+	}
+
+	p, err := ParsePattern(s[i:])
+	if err != nil {
+		return nil, err
+	}
+	r := p.Range()
+
+	switch op {
+	case "", "=", "==":
+		return Ranges{r}, nil
+	case "!=":
+		return Ranges{r}.Complement(), nil
+	case ">":
+		if r.Max == MaxNumber {
+			return Ranges{}, nil
+		}
+		return Ranges{{r.Max + 1, MaxNumber}}, nil
+	case ">=":
+		return Ranges{{r.Min, MaxNumber}}, nil
+	case "<":
+		if r.Min == 0 {
+			return Ranges{}, nil
+		}
+		return Ranges{{0, r.Min - 1}}, nil
+	case "<=":
+		return Ranges{{0, r.Max}}, nil
+	case "~":
+		if p.Fixed() > PartMinor {
+			return Ranges{{r.Min, NewPattern(r.Min, PartMinor).Range().Max}}, nil
+		}
+		return Ranges{r}, nil
+	}
+
+	// the only operator left is the caret.
+	switch n := p.Number(); {
+	case p.Fixed() == 0:
+		return Ranges{r}, nil
+	case n.Major() > 0 || p.Fixed() == PartMajor:
+		return Ranges{{r.Min, NewPattern(n, PartMajor).Range().Max}}, nil
+	case n.Minor() > 0 || p.Fixed() == PartMinor:
+		return Ranges{{r.Min, NewPattern(n, PartMinor).Range().Max}}, nil
+	}
+	return Ranges{r}, nil
+}
+
+func isOperator(s string) bool {
+	switch s {
+	case "=", "==", "!=", ">", ">=", "<", "<=", "~", "^":
+		return true
+	}
+	return false
+}
+
+func minNumber(a, b Number) Number {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxNumber(a, b Number) Number {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestParseRanges(t *testing.T) {
+	var tcs = []struct {
+		input, str string
+	}{
+		{"*", "0.0.0 - 65535.255.255"},
+		{"1.2.3", "1.2.3 - 1.2.3"},
+		{"=1.2.3", "1.2.3 - 1.2.3"},
+		{"== 1.2.3", "1.2.3 - 1.2.3"},
+		{"1.2", "1.2.0 - 1.2.255"},
+		{"2.x", "2.0.0 - 2.255.255"},
+		{">1.2", "1.3.0 - 65535.255.255"},
+		{">1.2.3", "1.2.4 - 65535.255.255"},
+		{">=1.2", "1.2.0 - 65535.255.255"},
+		{">= 1.2", "1.2.0 - 65535.255.255"},
+		{"<1.2", "0.0.0 - 1.1.255"},
+		{"<=1.2", "0.0.0 - 1.2.255"},
+		{"!=1.2", "0.0.0 - 1.1.255 || 1.3.0 - 65535.255.255"},
+		{"~1.2.3", "1.2.3 - 1.2.255"},
+		{"~1.2", "1.2.0 - 1.2.255"},
+		{"~1", "1.0.0 - 1.255.255"},
+		{"^1.2.3", "1.2.3 - 1.255.255"},
+		{"^0.2.3", "0.2.3 - 0.2.255"},
+		{"^0.0.3", "0.0.3 - 0.0.3"},
+		{"^0.0", "0.0.0 - 0.0.255"},
+		{"^0", "0.0.0 - 0.255.255"},
+		{"^*", "0.0.0 - 65535.255.255"},
+		{"1.2 - 2.3", "1.2.0 - 2.3.255"},
+		{">=1.2, <2", "1.2.0 - 1.255.255"},
+		{">=1.2 <2 !=1.4.1", "1.2.0 - 1.4.0 || 1.4.2 - 1.255.255"},
+		{"^1.2 || ^3", "1.2.0 - 1.255.255 || 3.0.0 - 3.255.255"},
+		{"^1 || ^2", "1.0.0 - 2.255.255"},
+		{"^1.2 || ~1.5.1", "1.2.0 - 1.255.255"},
+		{"<1 >2", "<0.0.0"},
+		{">65535.255.255", "<0.0.0"},
+		{"<0", "<0.0.0"},
+		{"<0.0.0", "<0.0.0"},
+	}
+
+	for i, tc := range tcs {
+		rs, err := semver.ParseRanges(tc.input)
+		if err != nil {
+			t.Errorf("tc[%d] no ranges parsing error expected, got: %s", i, err.Error())
+			continue
+		}
+		if s := rs.String(); s != tc.str {
+			t.Errorf("tc[%d] ranges mismatch expected: %s got: %s", i, tc.str, s)
+		}
+		if e := rs.IsEmpty(); e != (tc.str == "<0.0.0") {
+			t.Errorf("tc[%d] is empty mismatch expected: %t got: %t", i, tc.str == "<0.0.0", e)
+		}
+		if rr, err := semver.ParseRanges(rs.String()); err != nil {
+			t.Errorf("tc[%d] no ranges reparsing error expected, got: %s", i, err.Error())
+		} else if rr.String() != tc.str {
+			t.Errorf("tc[%d] reparsed ranges mismatch expected: %s got: %s", i, tc.str, rr)
+		}
+	}
+}
+
+func TestParseRangesError(t *testing.T) {
+	var tcs = []struct {
+		input string
+		err   error
+	}{
+		{"", semver.ErrorEmpty{}},
+		{"^1 ||", semver.ErrorEmpty{}},
+		{">=", semver.ErrorEmpty{}},
+		{"=>1.2", semver.ErrorInvalidCharacter{}},
+		{"^1.a", semver.ErrorInvalidCharacter{}},
+		{"1.2 - 1.a", semver.ErrorInvalidCharacter{}},
+		{">1.256", semver.ErrorMinorTooBig("1.256")},
+	}
+
+	for i, tc := range tcs {
+		if _, err := semver.ParseRanges(tc.input); err == nil {
+			t.Errorf("tc[%d] ranges parsing error expected, got: nil", i)
+		} else if e := err.(*semver.Error).Unwrap(); fmt.Sprintf("%T", e) != fmt.Sprintf("%T", tc.err) {
+			t.Errorf("tc[%d] ranges parsing error mismatch expected: %T got: %T", i, tc.err, e)
+		}
+	}
+}
+
+func TestRangesOperations(t *testing.T) {
+	a, _ := semver.ParseRanges("^1.2 || ^3")
+	b, _ := semver.ParseRanges(">=1.5 <3.1")
+
+	if exp, got := "1.5.0 - 1.255.255 || 3.0.0 - 3.0.255", a.Intersect(b).String(); exp != got {
+		t.Errorf("intersection mismatch expected: %s got: %s", exp, got)
+	}
+	if exp, got := "1.2.0 - 3.255.255", a.Union(b).String(); exp != got {
+		t.Errorf("union mismatch expected: %s got: %s", exp, got)
+	}
+	if exp, got := "0.0.0 - 1.1.255 || 2.0.0 - 2.255.255 || 4.0.0 - 65535.255.255", a.Complement().String(); exp != got {
+		t.Errorf("complement mismatch expected: %s got: %s", exp, got)
+	}
+	if max, ok := a.Max(); !ok || max != semver.NewNumber(3, 255, 255) {
+		t.Errorf("max mismatch expected: 3.255.255 got: %s", max)
+	}
+	if _, ok := (semver.Ranges{}).Max(); ok {
+		t.Error("expected empty ranges NOT to have a max")
+	}
+	if !a.Contains(semver.NewNumber(3, 1, 0)) || a.Contains(semver.NewNumber(2, 0, 0)) {
+		t.Errorf("unexpected containment for %s", a)
+	}
+	if exp, got := "1.0.0 - 1.2.255", semver.NewRanges(
+		semver.Range{Min: semver.NewNumber(1, 1, 0), Max: semver.NewNumber(1, 2, 255)},
+		semver.Range{Min: semver.NewNumber(1, 0, 0), Max: semver.NewNumber(1, 0, 255)},
+		semver.Range{Min: semver.NewNumber(2, 0, 0), Max: semver.NewNumber(1, 0, 0)},
+	).String(); exp != got {
+		t.Errorf("new ranges mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+func TestRangesMarshaling(t *testing.T) {
+	for _, s := range []string{"^1.2 || ^3", ">65535.255.255"} {
+		rs, _ := semver.ParseRanges(s)
+		testRangesMarshaling(t, rs)
+	}
+}
+
+func testRangesMarshaling(t *testing.T, rs semver.Ranges) {
+
+	if b, err := json.Marshal(rs); err != nil {
+		t.Errorf("no json marshaling error expected got: %s", err.Error())
+	} else {
+		var un semver.Ranges
+		if err := json.Unmarshal(b, &un); err != nil {
+			t.Errorf("no json unmarshaling error expected got: %s", err.Error())
+		} else if un.String() != rs.String() {
+			t.Errorf("json unmarshaling mismatch expected %s got: %s from %s", rs, un, string(b))
+		}
+	}
+
+	if b, err := yaml.Marshal(rs); err != nil {
+		t.Errorf("no yaml marshaling error expected got: %s", err.Error())
+	} else {
+		var un semver.Ranges
+		if err := yaml.Unmarshal(b, &un); err != nil {
+			t.Errorf("no yaml unmarshaling error expected got: %s", err.Error())
+		} else if un.String() != rs.String() {
+			t.Errorf("yaml unmarshaling mismatch expected %s got: %s from %s", rs, un, string(b))
+		}
+	}
+
+	if b, err := rs.MarshalText(); err != nil {
+		t.Errorf("no text marshaling error expected got: %s", err.Error())
+	} else {
+		var un semver.Ranges
+		if err := un.UnmarshalText(b); err != nil {
+			t.Errorf("no text unmarshaling error expected got: %s", err.Error())
+		} else if un.String() != rs.String() {
+			t.Errorf("text unmarshaling mismatch expected %s got: %s from %s", rs, un, string(b))
+		}
+	}
+}
+
+func TestRangesBinary(t *testing.T) {
+	rs, _ := semver.ParseRanges("^1.2 || ^3")
+
+	b, err := rs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("no binary marshaling error expected got: %s", err.Error())
+	}
+	if len(b) != 18 {
+		t.Errorf("binary length mismatch expected: 18 got: %d", len(b))
+	}
+
+	var un semver.Ranges
+	if err := un.UnmarshalBinary(b); err != nil {
+		t.Errorf("no binary unmarshaling error expected got: %s", err.Error())
+	} else if un.String() != rs.String() {
+		t.Errorf("binary unmarshaling mismatch expected %s got: %s", rs, un)
+	}
+
+	for i, data := range [][]byte{nil, {0}, b[:17], append(b, 0)} {
+		var ile semver.ErrorInvalidLength
+		if err := un.UnmarshalBinary(data); !errors.As(err, &ile) || int(ile) != len(data) {
+			t.Errorf("tc[%d] invalid length error expected got: %v", i, err)
+		}
+	}
+}
+
+func ExampleParseRanges() {
+	rs, err := semver.ParseRanges("^1.2 || 3.x")
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(rs.Contains(semver.NewNumber(1, 4, 0)), rs.Contains(semver.NewNumber(2, 0, 0)))
+	}
+	// Output: true false
+}
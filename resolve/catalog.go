@@ -0,0 +1,42 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package resolve
+
+import (
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Requirements maps package names to the constraints placed on them.
+type Requirements map[string]semver.Ranges
+
+// Catalog provides the packages the resolver chooses from.
+type Catalog interface {
+	// Versions returns every available version of the named package.
+	// Unknown packages have no versions.
+	Versions(name string) (semver.Numbers, error)
+
+	// Dependencies returns the Requirements of
+	// the specified version of the named package.
+	Dependencies(name string, version semver.Number) (Requirements, error)
+}
+
+// MapCatalog is an in-memory Catalog, mostly useful for fixtures;
+// it maps package names to their versions and their Requirements.
+type MapCatalog map[string]map[semver.Number]Requirements
+
+// Versions satisfies the Catalog interface.
+func (c MapCatalog) Versions(name string) (semver.Numbers, error) {
+	var ns semver.Numbers
+	for n := range c[name] {
+		ns = append(ns, n)
+	}
+	return ns, nil
+}
+
+// Dependencies satisfies the Catalog interface.
+func (c MapCatalog) Dependencies(name string, version semver.Number) (Requirements, error) {
+	return c[name][version], nil
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package resolve selects a version for every package in a dependency graph
+// whose versions are semver.Number values and whose dependencies are
+// expressed as semver.Ranges constraints.
+//
+// The resolver backtracks through the versions available in a Catalog,
+// always trying the newest ones first, and explains why no solution exists
+// when every combination of versions has been ruled out.
+package resolve
@@ -0,0 +1,262 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package resolve
+
+import (
+	"sort"
+	"strings"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// Solution maps package names to their selected version.
+type Solution map[string]semver.Number
+
+// Requirement is a constraint placed on a package by a specific
+// version of another one, or by the root Requirements when By is empty.
+type Requirement struct {
+	By      string
+	Version semver.Number
+	Ranges  semver.Ranges
+}
+
+// String satisfies the fmt.Stringer interface.
+func (r Requirement) String() string {
+	if r.By == "" {
+		return "root requires " + r.Ranges.String()
+	}
+	return r.By + " " + r.Version.GoString() + " requires " + r.Ranges.String()
+}
+
+// Conflict is the error produced when no Solution exists.
+// It describes the package for which no version could be selected.
+type Conflict struct {
+	// Package is the name of the package that could not be selected.
+	Package string
+	// Requirements are the constraints placed on Package at that point.
+	Requirements []Requirement
+	// Available are every version of Package, in ascending order.
+	Available semver.Numbers
+}
+
+// Error satisfies the error interface.
+func (c *Conflict) Error() string {
+	var b strings.Builder
+	b.WriteString("resolve: no version of \"")
+	b.WriteString(c.Package)
+	b.WriteString("\" satisfies every requirement:")
+	for _, r := range c.Requirements {
+		b.WriteString("\n\t")
+		b.WriteString(r.String())
+	}
+	b.WriteString("\n\tavailable versions: ")
+	if len(c.Available) == 0 {
+		b.WriteString("none")
+	}
+	for i, n := range c.Available {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(n.GoString())
+	}
+	return b.String()
+}
+
+// Resolve selects a version of every package reachable from root,
+// preferring the newest versions, such that every Requirement is satisfied.
+//
+// It returns a *Conflict error when no such selection exists,
+// or the error produced by the Catalog, if any.
+func Resolve(c Catalog, root Requirements) (Solution, error) {
+	r := &resolver{
+		catalog:  c,
+		versions: make(map[string]semver.Numbers),
+		deps:     make(map[dependant]Requirements),
+		selected: make(Solution),
+		reqs:     make(map[string][]Requirement),
+	}
+	r.push("", 0, root)
+
+	ok, err := r.solve()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, r.conflict
+	}
+	return r.selected, nil
+}
+
+type dependant struct {
+	name    string
+	version semver.Number
+}
+
+type resolver struct {
+	catalog  Catalog
+	versions map[string]semver.Numbers
+	deps     map[dependant]Requirements
+	selected Solution
+	reqs     map[string][]Requirement
+
+	// conflict is the one found with the most packages selected,
+	// which tends to be the most relevant.
+	conflict      *Conflict
+	conflictDepth int
+}
+
+// solve selects a version for the most constrained pending package and
+// recurses, backtracking whenever a selection leads to a conflict.
+func (r *resolver) solve() (bool, error) {
+	name, candidates, err := r.next()
+	if err != nil || name == "" {
+		return err == nil, err
+	}
+
+	for _, v := range candidates {
+		deps, err := r.dependencies(name, v)
+		if err != nil {
+			return false, err
+		}
+		if !r.compatible(name, v, deps) {
+			continue
+		}
+
+		r.selected[name] = v
+		r.push(name, v, deps)
+		if ok, err := r.solve(); ok || err != nil {
+			return ok, err
+		}
+		r.pop(deps)
+		delete(r.selected, name)
+	}
+
+	if len(candidates) == 0 {
+		r.fail(name, nil)
+	}
+	return false, nil
+}
+
+// next returns the pending package with the fewest candidate versions,
+// newest first. It returns an empty name if no package is pending.
+func (r *resolver) next() (string, semver.Numbers, error) {
+	var pending []string
+	for name := range r.reqs {
+		if _, ok := r.selected[name]; !ok {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+
+	var (
+		best       string
+		candidates semver.Numbers
+	)
+	for _, name := range pending {
+		versions, err := r.available(name)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var cs semver.Numbers
+	versions:
+		for i := len(versions) - 1; i >= 0; i-- {
+			for _, req := range r.reqs[name] {
+				if !req.Ranges.Contains(versions[i]) {
+					continue versions
+				}
+			}
+			cs = append(cs, versions[i])
+		}
+
+		if best == "" || len(cs) < len(candidates) {
+			best, candidates = name, cs
+		}
+		if len(cs) == 0 {
+			break
+		}
+	}
+	return best, candidates, nil
+}
+
+// compatible reports whether the dependencies of the specified version of
+// the named package are satisfied by the packages selected so far.
+func (r *resolver) compatible(name string, v semver.Number, deps Requirements) bool {
+	for _, dep := range sortedNames(deps) {
+		if sel, ok := r.selected[dep]; ok && !deps[dep].Contains(sel) {
+			r.fail(dep, &Requirement{name, v, deps[dep]})
+			return false
+		}
+	}
+	return true
+}
+
+func (r *resolver) push(name string, v semver.Number, deps Requirements) {
+	for _, dep := range sortedNames(deps) {
+		r.reqs[dep] = append(r.reqs[dep], Requirement{name, v, deps[dep]})
+	}
+}
+
+func (r *resolver) pop(deps Requirements) {
+	for dep := range deps {
+		if l := len(r.reqs[dep]) - 1; l > 0 {
+			r.reqs[dep] = r.reqs[dep][:l]
+		} else {
+			delete(r.reqs, dep)
+		}
+	}
+}
+
+func (r *resolver) fail(name string, extra *Requirement) {
+	if r.conflict != nil && len(r.selected) <= r.conflictDepth {
+		return
+	}
+
+	reqs := append([]Requirement(nil), r.reqs[name]...)
+	if extra != nil {
+		reqs = append(reqs, *extra)
+	}
+	available, _ := r.available(name)
+	r.conflict = &Conflict{name, reqs, available}
+	r.conflictDepth = len(r.selected)
+}
+
+// available returns every version of the named package in ascending order.
+func (r *resolver) available(name string) (semver.Numbers, error) {
+	if vs, ok := r.versions[name]; ok {
+		return vs, nil
+	}
+	vs, err := r.catalog.Versions(name)
+	if err != nil {
+		return nil, err
+	}
+	vs = append(semver.Numbers(nil), vs...)
+	sort.Sort(vs)
+	r.versions[name] = vs
+	return vs, nil
+}
+
+func (r *resolver) dependencies(name string, v semver.Number) (Requirements, error) {
+	k := dependant{name, v}
+	if deps, ok := r.deps[k]; ok {
+		return deps, nil
+	}
+	deps, err := r.catalog.Dependencies(name, v)
+	if err != nil {
+		return nil, err
+	}
+	r.deps[k] = deps
+	return deps, nil
+}
+
+func sortedNames(reqs Requirements) []string {
+	names := make([]string, 0, len(reqs))
+	for name := range reqs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package resolve_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/resolve"
+)
+
+func v(s string) semver.Number {
+	n, err := semver.ParseNumber(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func reqs(kv ...string) resolve.Requirements {
+	rs := make(resolve.Requirements)
+	for i := 0; i < len(kv); i += 2 {
+		r, err := semver.ParseRanges(kv[i+1])
+		if err != nil {
+			panic(err)
+		}
+		rs[kv[i]] = r
+	}
+	return rs
+}
+
+var catalog = resolve.MapCatalog{
+	"host": {
+		v("1.0.0"): nil,
+		v("1.4.0"): nil,
+		v("2.0.0"): nil,
+	},
+	"auth": {
+		v("1.0.0"): reqs("host", "^1"),
+		v("1.1.0"): reqs("host", "^1.2", "log", "^1"),
+		v("2.0.0"): reqs("host", "^2", "log", "^2"),
+	},
+	"log": {
+		v("1.0.0"): nil,
+		v("1.3.0"): nil,
+		v("2.0.0"): reqs("host", "^2"),
+	},
+	"metrics": {
+		v("1.0.0"): reqs("log", "^1.3"),
+		v("1.1.0"): reqs("log", "^2"),
+	},
+}
+
+func TestResolve(t *testing.T) {
+	var tcs = []struct {
+		root resolve.Requirements
+		exp  string
+	}{
+		{reqs(), "map[]"},
+		{reqs("host", "*"), "map[host:2]"},
+		{reqs("auth", "*"), "map[auth:2 host:2 log:2]"},
+		{reqs("auth", "*", "host", "^1"), "map[auth:1.1 host:1.4 log:1.3]"},
+		{reqs("auth", "*", "metrics", "*", "host", "1.x"), "map[auth:1.1 host:1.4 log:1.3 metrics:1]"},
+		{reqs("metrics", "*", "host", "<1.2"), "map[host:1 log:1.3 metrics:1]"},
+		{reqs("auth", "*", "metrics", "*"), "map[auth:2 host:2 log:2 metrics:1.1]"},
+	}
+
+	for i, tc := range tcs {
+		if sol, err := resolve.Resolve(catalog, tc.root); err != nil {
+			t.Errorf("tc[%d] no resolution error expected got: %s", i, err.Error())
+		} else if got := fmt.Sprint(sol); got != tc.exp {
+			t.Errorf("tc[%d] solution mismatch expected: %s got: %s", i, tc.exp, got)
+		}
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	var tcs = []struct {
+		root resolve.Requirements
+		pkg  string
+		msg  []string
+	}{
+		{
+			reqs("missing", "^1"), "missing",
+			[]string{
+				`resolve: no version of "missing" satisfies every requirement:`,
+				"\troot requires 1.0.0 - 1.255.255",
+				"\tavailable versions: none",
+			},
+		},
+		{
+			reqs("auth", "^2", "host", "^1"), "host",
+			[]string{
+				`resolve: no version of "host" satisfies every requirement:`,
+				"\troot requires 1.0.0 - 1.255.255",
+				"\tauth 2.0.0 requires 2.0.0 - 2.255.255",
+				"\tavailable versions: 1.0.0, 1.4.0, 2.0.0",
+			},
+		},
+		{
+			reqs("metrics", "1.1", "auth", "1.1"), "log",
+			[]string{
+				`resolve: no version of "log" satisfies every requirement:`,
+			},
+		},
+	}
+
+	for i, tc := range tcs {
+		_, err := resolve.Resolve(catalog, tc.root)
+
+		var c *resolve.Conflict
+		if !errors.As(err, &c) {
+			t.Errorf("tc[%d] conflict expected got: %v", i, err)
+			continue
+		}
+		if c.Package != tc.pkg {
+			t.Errorf("tc[%d] conflicting package mismatch expected: %s got: %s", i, tc.pkg, c.Package)
+		}
+		if exp, got := strings.Join(tc.msg, "\n"), c.Error(); !strings.HasPrefix(got, exp) {
+			t.Errorf("tc[%d] conflict message mismatch expected: %s got: %s", i, exp, got)
+		}
+	}
+}
+
+// backtracking makes the resolver select and then drop app 2,
+// which shares its requirement on lib with app 1.
+var backtracking = resolve.MapCatalog{
+	"app": {
+		v("1.0.0"): reqs("lib", "^1", "db", "^1"),
+		v("2.0.0"): reqs("lib", "^1", "db", "^2"),
+	},
+	"db": {
+		v("1.0.0"): nil,
+		v("2.0.0"): reqs("lib", "^2"),
+	},
+	"lib": {
+		v("1.0.0"): nil,
+		v("1.1.0"): nil,
+		v("2.0.0"): nil,
+	},
+}
+
+func TestResolveBacktrack(t *testing.T) {
+	var tcs = []struct {
+		root resolve.Requirements
+		exp  string
+	}{
+		{reqs("app", "*"), "map[app:1 db:1 lib:1.1]"},
+		{reqs("app", "*", "lib", "<1.1"), "map[app:1 db:1 lib:1]"},
+		{reqs("app", "*", "db", "*"), "map[app:1 db:1 lib:1.1]"},
+	}
+
+	for i, tc := range tcs {
+		if sol, err := resolve.Resolve(backtracking, tc.root); err != nil {
+			t.Errorf("tc[%d] no resolution error expected got: %s", i, err.Error())
+		} else if got := fmt.Sprint(sol); got != tc.exp {
+			t.Errorf("tc[%d] solution mismatch expected: %s got: %s", i, tc.exp, got)
+		}
+	}
+
+	_, err := resolve.Resolve(backtracking, reqs("app", "*", "lib", "^2"))
+	var c *resolve.Conflict
+	if !errors.As(err, &c) {
+		t.Fatalf("conflict expected got: %v", err)
+	}
+	if exp, got := "[root requires 2.0.0 - 2.255.255 app 2.0.0 requires 1.0.0 - 1.255.255]", fmt.Sprint(c.Requirements); exp != got {
+		t.Errorf("conflicting requirements mismatch expected: %s got: %s", exp, got)
+	}
+}
+
+type failingCatalog struct{ resolve.MapCatalog }
+
+var errCatalog = errors.New("catalog unavailable")
+
+func (failingCatalog) Versions(string) (semver.Numbers, error) { return nil, errCatalog }
+
+func TestResolveCatalogError(t *testing.T) {
+	if _, err := resolve.Resolve(failingCatalog{catalog}, reqs("auth", "*")); err != errCatalog {
+		t.Errorf("catalog error expected got: %v", err)
+	}
+}
+
+func ExampleResolve() {
+	sol, err := resolve.Resolve(catalog, reqs("auth", "*", "host", "^1"))
+	if err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println(sol["auth"], sol["host"], sol["log"])
+	}
+	// Output: 1.1 1.4 1.3
+}
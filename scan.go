@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver
+
+// Match is a Number found within a text.
+type Match struct {
+	Number Number
+	// Start and End are the byte offsets of the Token within the text.
+	Start, End int
+	// Token is the text the Number was parsed from, e.g. "v1.2.3".
+	Token string
+}
+
+// FindAll returns every Number mentioned in the text.
+//
+// Numbers are found as words made of two or three components separated by
+// dots, such as "1.2" or "1.2.3", which may be prefixed by a 'v' or 'V',
+// in which case a single component, as in "v2", is enough. Words with more
+// components, such as "10.0.0.1", and components out of bounds are ignored.
+func FindAll(text []byte) []Match {
+	var ms []Match
+	for i := 0; ; {
+		m, ok := findNumber(text, i)
+		if !ok {
+			return ms
+		}
+		ms = append(ms, m)
+		i = m.End
+	}
+}
+
+// ScanNumbers is a bufio.SplitFunc that returns the tokens
+// of the Numbers mentioned in the text, as FindAll finds them.
+func ScanNumbers(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	m, ok := findNumber(data, 0)
+	switch {
+	case ok && (atEOF || m.End+1 < len(data)):
+		return m.End, data[m.Start:m.End], nil
+	case ok:
+		// the token may go on in the data still to be read.
+		return m.Start, nil, nil
+	case atEOF:
+		return len(data), nil, nil
+	}
+
+	// keep the word at the end, it may be the beginning of a Number.
+	i := len(data)
+	for i > 0 && (isWordByte(data[i-1]) || data[i-1] == '.') {
+		i--
+	}
+	return i, nil, nil
+}
+
+// findNumber returns the first Number mentioned in data from the offset.
+func findNumber(data []byte, from int) (Match, bool) {
+	for i := from; i < len(data); i++ {
+		if i > 0 && (isWordByte(data[i-1]) || data[i-1] == '.') {
+			continue
+		}
+
+		j := i
+		if data[j] == 'v' || data[j] == 'V' {
+			j++
+		}
+		start := j
+		for j < len(data) && isDigit(data[j]) {
+			j++
+		}
+		if j == start {
+			continue
+		}
+		dots := 0
+		for j+1 < len(data) && data[j] == '.' && isDigit(data[j+1]) {
+			for j += 2; j < len(data) && isDigit(data[j]); j++ {
+			}
+			dots++
+		}
+		if j < len(data) && isWordByte(data[j]) || dots > 2 || dots == 0 && start == i {
+			continue
+		}
+
+		n, err := ParseNumber(string(data[start:j]))
+		if err != nil {
+			continue
+		}
+		return Match{n, i, j, string(data[i:j])}, true
+	}
+	return Match{}, false
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// isWordByte tells whether c may be part of a word,
+// bytes of multi-byte UTF-8 sequences included.
+func isWordByte(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package semver_test
+
+import (
+	"bufio"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+func TestFindAll(t *testing.T) {
+	var tcs = []struct {
+		text string
+		exp  []string
+	}{
+		{"Release 1.2.3 (2020-06-01) supersedes v1.2, v2 and V3.0.1.", []string{"1.2.3@8", "v1.2@38", "v2@44", "V3.0.1@51"}},
+		{"<li><a href=\"/r/1.4.0\">1.4.0</a></li>", []string{"1.4.0@16", "1.4.0@23"}},
+		{"agent-1.4.2-linux-amd64.tar.gz", []string{"1.4.2@6"}},
+		{"host 10.0.0.1 runs 2", nil},
+		{"a1.2 1.2b x.1.2 1.2.3.4 1.256 _1.0 ñ1.0", nil},
+		{"(1.0),1.1;v1.2", []string{"1.0@1", "1.1@6", "v1.2@10"}},
+		{"version 1.", nil},
+		{"", nil},
+	}
+
+	for i, tc := range tcs {
+		var got []string
+		for _, m := range semver.FindAll([]byte(tc.text)) {
+			if tc.text[m.Start:m.End] != m.Token {
+				t.Errorf("tc[%d] token mismatch expected: %s got: %s", i, tc.text[m.Start:m.End], m.Token)
+			}
+			got = append(got, fmt.Sprintf("%s@%d", m.Token, m.Start))
+		}
+		if !reflect.DeepEqual(got, tc.exp) {
+			t.Errorf("tc[%d] mismatch expected: %v got: %v", i, tc.exp, got)
+		}
+	}
+}
+
+func TestScanNumbers(t *testing.T) {
+	text := "v1 1.2.3.4 Release 1.2.3 supersedes v1.2.\nabc1.0 x 10.20 ends at 3.4"
+	exp := []string{"v1", "1.2.3", "v1.2", "10.20", "3.4"}
+
+	for _, r := range []func() *bufio.Scanner{
+		func() *bufio.Scanner { return bufio.NewScanner(strings.NewReader(text)) },
+		func() *bufio.Scanner { return bufio.NewScanner(iotest.OneByteReader(strings.NewReader(text))) },
+	} {
+		s := r()
+		s.Split(semver.ScanNumbers)
+		var got []string
+		for s.Scan() {
+			got = append(got, s.Text())
+		}
+		if err := s.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, exp) {
+			t.Errorf("mismatch expected: %v got: %v", exp, got)
+		}
+	}
+}
+
+func ExampleFindAll() {
+	for _, m := range semver.FindAll([]byte("Upgrade from v1.4 to 2.0.1 before 2021.")) {
+		fmt.Println(m.Token, m.Number.GoString(), m.Start, m.End)
+	}
+	// Output:
+	// v1.4 1.4.0 13 17
+	// 2.0.1 2.0.1 21 26
+}
@@ -0,0 +1,441 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package versioned encodes and validates structs whose fields are only
+// available in some versions of an API, as declared by struct tags:
+//
+//	type User struct {
+//		Name   string `json:"name"`
+//		Avatar string `json:"avatar" semver:"since=1.2"`
+//		Login  string `json:"login" semver:"until=2.0"`
+//	}
+//
+// A field is available from its since Number, inclusive, to its until
+// Number, exclusive; either bound may be omitted. Fields are named after
+// their encoding/json tags and, when several share a name, the one
+// encoding/json would pick is taken: the least nested one or, if nested
+// as deep as others, the only one tagged; otherwise all of them are ignored.
+package versioned
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+// TagError is the error produced when a semver struct tag is malformed.
+type TagError struct {
+	Type  reflect.Type
+	Field string
+	Err   error
+}
+
+// Unwrap returns the error TagError is wrapping.
+func (e *TagError) Unwrap() error { return e.Err }
+
+// Error satisfies the error interface.
+func (e *TagError) Error() string {
+	return fmt.Sprintf("versioned: invalid tag on %s.%s: %s", e.Type, e.Field, e.Err.Error())
+}
+
+// FieldError is the error produced when validating
+// a field that is newer than the client's version.
+type FieldError struct {
+	// Path locates the field, e.g.: "profile.links[2].url".
+	Path   string
+	Since  semver.Number
+	Client semver.Number
+}
+
+// Error satisfies the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("versioned: field %q requires %#v, but the client is %#v", e.Path, e.Since, e.Client)
+}
+
+type field struct {
+	name         string
+	tagged       bool
+	index        []int
+	since, until semver.Number
+	hasUntil     bool
+	omitEmpty    bool
+}
+
+func (f field) available(n semver.Number) bool {
+	return n >= f.since && (!f.hasUntil || n < f.until)
+}
+
+var cache sync.Map // map[reflect.Type][]field
+
+// fields returns the encodable fields of the struct type t, including
+// the ones promoted from embedded structs, in declaration order.
+func fields(t reflect.Type) ([]field, error) {
+	if fs, ok := cache.Load(t); ok {
+		return fs.([]field), nil
+	}
+
+	all, err := collect(t)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		switch {
+		case a.name != b.name:
+			return a.name < b.name
+		case len(a.index) != len(b.index):
+			return len(a.index) < len(b.index)
+		}
+		return a.tagged && !b.tagged
+	})
+
+	var fs []field
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].name == all[i].name {
+			j++
+		}
+		// like encoding/json, the first field of the name dominates unless
+		// it ties with the second one in both depth and being tagged.
+		if j-i == 1 || len(all[i].index) < len(all[i+1].index) || all[i].tagged != all[i+1].tagged {
+			fs = append(fs, all[i])
+		}
+		i = j
+	}
+	sort.Slice(fs, func(i, j int) bool { return before(fs[i].index, fs[j].index) })
+
+	cache.Store(t, fs)
+	return fs, nil
+}
+
+// before reports whether the field at index a is declared before the one at b.
+func before(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// collect returns every encodable field of the struct type t,
+// including all of those promoted from embedded structs.
+func collect(t reflect.Type) ([]field, error) {
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		embedded := sf.Anonymous && indirect(sf.Type).Kind() == reflect.Struct
+		if sf.PkgPath != "" && !embedded {
+			continue
+		}
+
+		tag, opts := sf.Tag.Get("json"), ""
+		if tag == "-" {
+			continue
+		}
+		if j := strings.IndexByte(tag, ','); j >= 0 {
+			tag, opts = tag[:j], tag[j:]
+		}
+
+		name := tag
+		if name == "" && !embedded {
+			name = sf.Name
+		}
+		if name != "" && sf.PkgPath != "" {
+			continue
+		}
+
+		f := field{name: name, tagged: tag != "", index: sf.Index, omitEmpty: strings.Contains(opts, ",omitempty")}
+		if err := parseTag(sf.Tag.Get("semver"), &f); err != nil {
+			return nil, &TagError{t, sf.Name, err}
+		}
+
+		if name == "" {
+			promoted, err := collect(indirect(sf.Type))
+			if err != nil {
+				return nil, err
+			}
+			for _, ef := range promoted {
+				ef.index = append(append([]int(nil), sf.Index...), ef.index...)
+				if f.since > ef.since {
+					ef.since = f.since
+				}
+				if f.hasUntil && (!ef.hasUntil || f.until < ef.until) {
+					ef.until, ef.hasUntil = f.until, true
+				}
+				fs = append(fs, ef)
+			}
+			continue
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+func parseTag(tag string, f *field) error {
+	if tag == "" {
+		return nil
+	}
+	for _, kv := range strings.Split(tag, ",") {
+		j := strings.IndexByte(kv, '=')
+		if j < 0 {
+			return fmt.Errorf("expected key=value, got: %q", kv)
+		}
+		n, err := semver.ParseNumber(strings.TrimSpace(kv[j+1:]))
+		if err != nil {
+			return err
+		}
+		switch strings.TrimSpace(kv[:j]) {
+		case "since":
+			f.since = n
+		case "until":
+			f.until, f.hasUntil = n, true
+		default:
+			return fmt.Errorf("unknown key: %q", kv[:j])
+		}
+	}
+	return nil
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+var (
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// opaque reports whether values of type t encode themselves
+// and, thus, must not be filtered.
+func opaque(t reflect.Type) bool {
+	return t.Implements(jsonMarshaler) || t.Implements(textMarshaler) ||
+		reflect.PtrTo(t).Implements(jsonMarshaler) || reflect.PtrTo(t).Implements(textMarshaler)
+}
+
+// Encode returns the fields of v, a struct or a pointer to one, that are
+// available in the target Number, keyed by their name. Nested structs,
+// including those within slices, arrays and maps, are filtered as well.
+func Encode(v interface{}, target semver.Number) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("versioned: struct expected, got: %T", v)
+	}
+	return encodeStruct(rv, target)
+}
+
+// Marshal returns the JSON encoding of the fields of v
+// that are available in the target Number.
+func Marshal(v interface{}, target semver.Number) ([]byte, error) {
+	m, err := Encode(v, target)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+func encodeStruct(v reflect.Value, target semver.Number) (map[string]interface{}, error) {
+	fs, err := fields(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, len(fs))
+	for _, f := range fs {
+		if !f.available(target) {
+			continue
+		}
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if m[f.name], err = encodeValue(fv, target); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex,
+// but it reports nil embedded pointers instead of panicking.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func encodeValue(v reflect.Value, target semver.Number) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if opaque(v.Type()) {
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(v.Elem(), target)
+	case reflect.Struct:
+		return encodeStruct(v, target)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() || !filtered(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			var err error
+			if s[i], err = encodeValue(v.Index(i), target); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	case reflect.Map:
+		if v.IsNil() || !filtered(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		m := make(map[string]interface{}, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			ev, err := encodeValue(it.Value(), target)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(it.Key().Interface())] = ev
+		}
+		return m, nil
+	}
+	return v.Interface(), nil
+}
+
+// filtered reports whether values of type t may hold structs to filter.
+func filtered(t reflect.Type) bool {
+	if opaque(t) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return filtered(t.Elem())
+	}
+	return false
+}
+
+// Validate checks that the JSON encoded data, meant to be decoded into v,
+// a struct or a pointer to one, holds no fields newer than the client
+// Number. Nested objects are validated as well.
+//
+// It returns a *FieldError for the first such field, in key order.
+func Validate(data []byte, v interface{}, client semver.Number) error {
+	t := reflect.TypeOf(v)
+	if t == nil || indirect(t).Kind() != reflect.Struct {
+		return fmt.Errorf("versioned: struct expected, got: %T", v)
+	}
+	return validate(data, indirect(t), client, "")
+}
+
+func validate(data json.RawMessage, t reflect.Type, client semver.Number, path string) error {
+	t = indirect(t)
+	if opaque(t) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return nil
+		}
+		fs, err := fields(t)
+		if err != nil {
+			return err
+		}
+		for _, key := range sortedKeys(obj) {
+			f, ok := lookup(fs, key)
+			if !ok {
+				continue
+			}
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			if client < f.since {
+				return &FieldError{p, f.since, client}
+			}
+			if err := validate(obj[key], t.FieldByIndex(f.index).Type, client, p); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		var arr []json.RawMessage
+		if json.Unmarshal(data, &arr) != nil {
+			return nil
+		}
+		for i, e := range arr {
+			if err := validate(e, t.Elem(), client, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return nil
+		}
+		for _, key := range sortedKeys(obj) {
+			if err := validate(obj[key], t.Elem(), client, path+"."+key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookup finds the field named key, preferring an exact match
+// but accepting a case-insensitive one, like encoding/json does.
+func lookup(fs []field, key string) (field, bool) {
+	for _, f := range fs {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fs {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package versioned_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/versioned"
+)
+
+type Link struct {
+	URL   string `json:"url"`
+	Title string `json:"title" semver:"since=1.3"`
+}
+
+type Audit struct {
+	Created string `json:"created"`
+	Updated string `json:"updated" semver:"since=1.1"`
+}
+
+type User struct {
+	Audit  `semver:"since=1.0.1"`
+	Name   string            `json:"name"`
+	Avatar string            `json:"avatar,omitempty" semver:"since=1.2"`
+	Login  string            `json:"login" semver:"until=2.0"`
+	Links  []Link            `json:"links,omitempty"`
+	Extra  map[string]*Link  `json:"extra,omitempty" semver:"since=1.2"`
+	Tags   []string          `json:"tags,omitempty"`
+	Plain  int               `semver:"since=1.1,until=1.5"`
+	Since  semver.Number     `json:"since"`
+	Meta   map[string]string `json:"-"`
+	secret string
+}
+
+var user = User{
+	Audit:  Audit{"2020-01-01", "2020-02-01"},
+	Name:   "ana",
+	Avatar: "a.png",
+	Login:  "ana1",
+	Links:  []Link{{"https://a", "A"}},
+	Extra:  map[string]*Link{"home": {"https://h", "H"}},
+	Tags:   []string{"x"},
+	Plain:  7,
+	Since:  semver.NewNumber(1, 0, 0),
+	Meta:   map[string]string{"a": "b"},
+	secret: "s",
+}
+
+func TestMarshal(t *testing.T) {
+	var tcs = []struct {
+		target string
+		exp    string
+	}{
+		{"1.0", `{"links":[{"url":"https://a"}],"login":"ana1","name":"ana","since":"1","tags":["x"]}`},
+		{"1.1", `{"Plain":7,"created":"2020-01-01","links":[{"url":"https://a"}],"login":"ana1","name":"ana","since":"1","tags":["x"],"updated":"2020-02-01"}`},
+		{"1.5", `{"avatar":"a.png","created":"2020-01-01","extra":{"home":{"title":"H","url":"https://h"}},"links":[{"title":"A","url":"https://a"}],"login":"ana1","name":"ana","since":"1","tags":["x"],"updated":"2020-02-01"}`},
+		{"2.0", `{"avatar":"a.png","created":"2020-01-01","extra":{"home":{"title":"H","url":"https://h"}},"links":[{"title":"A","url":"https://a"}],"name":"ana","since":"1","tags":["x"],"updated":"2020-02-01"}`},
+	}
+
+	for i, tc := range tcs {
+		n, _ := semver.ParseNumber(tc.target)
+		if b, err := versioned.Marshal(&user, n); err != nil {
+			t.Errorf("tc[%d] no marshaling error expected got: %s", i, err.Error())
+		} else if got := string(b); got != tc.exp {
+			t.Errorf("tc[%d] marshaling for %s mismatch expected: %s got: %s", i, n, tc.exp, got)
+		}
+	}
+
+	if _, err := versioned.Encode(1, 0); err == nil {
+		t.Error("struct expected error expected got: nil")
+	}
+}
+
+type Base struct {
+	ID    string `json:"id" semver:"since=1.1"`
+	Kind  string `semver:"since=1.1"`
+	Note  string `json:"note"`
+	Title string `json:"Label"`
+}
+
+type Other struct {
+	Kind  string
+	Note  string `json:"note"`
+	Label string
+}
+
+type Outer struct {
+	ID string `json:"id"`
+	Base
+	*Other
+}
+
+func TestMarshalConflicts(t *testing.T) {
+	v := Outer{"outer", Base{"inner", "base", "base", "title"}, &Other{"other", "other", "label"}}
+
+	b, err := versioned.Marshal(v, semver.NewNumber(2, 0, 0))
+	if err != nil {
+		t.Fatalf("no marshaling error expected got: %s", err.Error())
+	}
+	var got, exp map[string]interface{}
+	std, _ := json.Marshal(v)
+	json.Unmarshal(b, &got)
+	json.Unmarshal(std, &exp)
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("marshaling mismatch expected: %s got: %s", std, b)
+	}
+
+	if err := versioned.Validate([]byte(`{"id":"x","Kind":"y"}`), Outer{}, semver.NewNumber(1, 0, 0)); err != nil {
+		t.Errorf("no validation error expected got: %s", err.Error())
+	}
+}
+
+type Bad struct {
+	A string `semver:"since=1.x"`
+}
+
+type Unknown struct {
+	A string `semver:"after=1"`
+}
+
+func TestTagError(t *testing.T) {
+	var te *versioned.TagError
+	if _, err := versioned.Encode(Bad{}, 0); !errors.As(err, &te) || te.Field != "A" {
+		t.Errorf("tag error expected got: %v", err)
+	}
+	var ice semver.ErrorInvalidCharacter
+	if _, err := versioned.Encode(Bad{}, 0); !errors.As(err, &ice) {
+		t.Errorf("invalid character error expected got: %v", err)
+	}
+	if err := versioned.Validate([]byte(`{"A":""}`), Unknown{}, 0); !errors.As(err, &te) {
+		t.Errorf("tag error expected got: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var tcs = []struct {
+		data   string
+		client string
+		path   string
+	}{
+		{`{"name":"ana","login":"x"}`, "1.0", ""},
+		{`{"name":"ana","avatar":"a.png"}`, "1.1", "avatar"},
+		{`{"name":"ana","AVATAR":"a.png"}`, "1.1", "AVATAR"},
+		{`{"name":"ana","avatar":"a.png"}`, "1.2", ""},
+		{`{"links":[{"url":"u"},{"url":"u","title":"t"}]}`, "1.2", "links[1].title"},
+		{`{"extra":{"home":{"title":"t"}}}`, "1.2", "extra.home.title"},
+		{`{"updated":"now"}`, "1.0.5", "updated"},
+		{`{"created":"now"}`, "1.0", "created"},
+		{`{"unknown":1,"links":3}`, "0", ""},
+	}
+
+	for i, tc := range tcs {
+		client, _ := semver.ParseNumber(tc.client)
+		err := versioned.Validate([]byte(tc.data), (*User)(nil), client)
+		if tc.path == "" {
+			if err != nil {
+				t.Errorf("tc[%d] no validation error expected got: %s", i, err.Error())
+			}
+			continue
+		}
+		var fe *versioned.FieldError
+		if !errors.As(err, &fe) {
+			t.Errorf("tc[%d] field error expected got: %v", i, err)
+		} else if fe.Path != tc.path || fe.Client != client {
+			t.Errorf("tc[%d] field error mismatch expected: %s got: %s", i, tc.path, fe.Path)
+		}
+	}
+
+	if err := versioned.Validate(nil, 1, 0); err == nil {
+		t.Error("struct expected error expected got: nil")
+	}
+}
+
+func ExampleMarshal() {
+	type Profile struct {
+		Name   string `json:"name"`
+		Avatar string `json:"avatar" semver:"since=1.2"`
+	}
+
+	p := Profile{"ana", "a.png"}
+	old, _ := versioned.Marshal(p, semver.NewNumber(1, 1, 0))
+	current, _ := versioned.Marshal(p, semver.NewNumber(1, 2, 0))
+
+	fmt.Println(string(old))
+	fmt.Println(string(current))
+	// Output:
+	// {"name":"ana"}
+	// {"avatar":"a.png","name":"ana"}
+}
+
+func ExampleValidate() {
+	type Profile struct {
+		Name   string `json:"name"`
+		Avatar string `json:"avatar" semver:"since=1.2"`
+	}
+
+	err := versioned.Validate([]byte(`{"name":"ana","avatar":"a.png"}`), Profile{}, semver.NewNumber(1, 1, 0))
+	fmt.Println(err)
+	// Output: versioned: field "avatar" requires 1.2.0, but the client is 1.1.0
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package versionfile locates the version stored in well-known kinds of
+// files, parses it into a semver.Number and rewrites it in place,
+// leaving the rest of the file untouched.
+//
+// The supported kinds of files are plain VERSION files, Go source files
+// declaring a Version constant, npm package.json files and Helm Chart.yaml
+// files. A leading 'v', as in "v1.2.3", is preserved.
+package versionfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	semver "github.com/vilarfg/go-semver32"
+)
+
+var (
+	// ErrUnknownKind is the error produced for files of unsupported kinds.
+	ErrUnknownKind = errors.New("versionfile: unknown kind of file")
+	// ErrNotFound is the error produced when a file holds no version.
+	ErrNotFound = errors.New("versionfile: version not found")
+)
+
+// Kind identifies a kind of file holding a version.
+type Kind byte
+
+const (
+	// Plain is a file holding just the version, such as VERSION.
+	Plain Kind = iota + 1
+	// GoConst is a Go source file declaring: const Version = "1.2.3".
+	GoConst
+	// PackageJSON is an npm package.json file.
+	PackageJSON
+	// ChartYAML is a Helm Chart.yaml file.
+	ChartYAML
+)
+
+// String satisfies the fmt.Stringer interface.
+func (k Kind) String() string {
+	switch k {
+	case Plain:
+		return "plain"
+	case GoConst:
+		return "go"
+	case PackageJSON:
+		return "package.json"
+	case ChartYAML:
+		return "Chart.yaml"
+	}
+	return "unknown"
+}
+
+// DetectKind returns the Kind of the file, based on its name.
+func DetectKind(path string) (Kind, bool) {
+	switch base := filepath.Base(path); {
+	case base == "package.json":
+		return PackageJSON, true
+	case base == "Chart.yaml":
+		return ChartYAML, true
+	case filepath.Ext(base) == ".go":
+		return GoConst, true
+	case strings.EqualFold(strings.TrimSuffix(base, filepath.Ext(base)), "VERSION"):
+		return Plain, true
+	}
+	return 0, false
+}
+
+// Version is a version located within the content of a file.
+type Version struct {
+	Number semver.Number
+	// Start and End are the byte offsets of the version within the
+	// content, excluding any quotes and leading 'v'.
+	Start, End int
+}
+
+// Locate finds the version within the content of a file of the given Kind.
+func Locate(kind Kind, content []byte) (Version, error) {
+	var (
+		start, end int
+		err        error
+	)
+	switch kind {
+	case Plain:
+		start, end, err = locatePlain(content)
+	case GoConst:
+		start, end, err = locateGo(content)
+	case PackageJSON:
+		start, end, err = locateJSON(content)
+	case ChartYAML:
+		start, end, err = locateYAML(content)
+	default:
+		err = ErrUnknownKind
+	}
+	if err != nil {
+		return Version{}, err
+	}
+
+	if start < end && content[start] == 'v' {
+		start++
+	}
+	n, err := semver.ParseNumber(string(content[start:end]))
+	if err != nil {
+		return Version{}, err
+	}
+	return Version{n, start, end}, nil
+}
+
+// Rewrite returns a copy of the content with the located version
+// replaced by n, formatted as major.minor.patch.
+func Rewrite(content []byte, v Version, n semver.Number) []byte {
+	s := n.GoString()
+	b := make([]byte, 0, len(content)-(v.End-v.Start)+len(s))
+	b = append(b, content[:v.Start]...)
+	b = append(b, s...)
+	return append(b, content[v.End:]...)
+}
+
+func locatePlain(content []byte) (int, int, error) {
+	start := len(content) - len(bytes.TrimLeft(content, " \t\r\n"))
+	end := start
+	for end < len(content) && !strings.ContainsRune(" \t\r\n", rune(content[end])) {
+		end++
+	}
+	if start == end {
+		return 0, 0, ErrNotFound
+	}
+	return start, end, nil
+}
+
+func locateGo(content []byte) (int, int, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name != "Version" || i >= len(vs.Values) {
+					continue
+				}
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					s, err := strconv.Unquote(lit.Value)
+					if err != nil || len(s) != len(lit.Value)-2 {
+						return 0, 0, ErrNotFound
+					}
+					start := fset.Position(lit.Pos()).Offset + 1
+					return start, start + len(s), nil
+				}
+			}
+		}
+	}
+	return 0, 0, ErrNotFound
+}
+
+func locateJSON(content []byte) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+
+	var (
+		depth     int
+		expectKey bool
+		key       string
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return 0, 0, ErrNotFound
+		} else if err != nil {
+			return 0, 0, err
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+				expectKey = depth == 1 && d == '{'
+			default:
+				depth--
+				expectKey = depth == 1
+			}
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+
+		if expectKey {
+			key, expectKey = tok.(string), false
+			continue
+		}
+		if s, ok := tok.(string); ok && key == "version" {
+			end := int(dec.InputOffset()) - 1
+			start := bytes.LastIndexByte(content[:end], '"') + 1
+			if end-start != len(s) {
+				return 0, 0, ErrNotFound
+			}
+			return start, end, nil
+		}
+		expectKey = true
+	}
+}
+
+func locateYAML(content []byte) (int, int, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return 0, 0, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return 0, 0, ErrNotFound
+	}
+
+	m := doc.Content[0].Content
+	for i := 0; i+1 < len(m); i += 2 {
+		if m[i].Value != "version" || m[i+1].Kind != yaml.ScalarNode {
+			continue
+		}
+		v := m[i+1]
+
+		// lines and columns are 1-based, columns count runes.
+		start := 0
+		for line := 1; line < v.Line; line++ {
+			start += bytes.IndexByte(content[start:], '\n') + 1
+		}
+		for col := 1; col < v.Column; col++ {
+			_, size := utf8.DecodeRune(content[start:])
+			start += size
+		}
+		if v.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			start++
+		}
+
+		end := start + len(v.Value)
+		if end > len(content) || string(content[start:end]) != v.Value {
+			return 0, 0, ErrNotFound
+		}
+		return start, end, nil
+	}
+	return 0, 0, ErrNotFound
+}
+
+// Result describes the bump of the version stored in a file.
+type Result struct {
+	Path     string
+	Kind     Kind
+	Old, New semver.Number
+	// Diff is a unified diff of the change.
+	Diff string
+}
+
+// Bump bumps the specified component of the version stored in the file
+// and rewrites it, unless dryRun is set.
+func Bump(path string, p semver.Part, dryRun bool) (Result, error) {
+	kind, ok := DetectKind(path)
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %s", ErrUnknownKind, path)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Result{}, err
+	}
+	v, err := Locate(kind, content)
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", path, err)
+	}
+	n, err := v.Number.Bump(p)
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	rewritten := Rewrite(content, v, n)
+	r := Result{path, kind, v.Number, n, diff(path, content, rewritten, v.Start)}
+	if dryRun {
+		return r, nil
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return Result{}, err
+	}
+	return r, ioutil.WriteFile(path, rewritten, fi.Mode().Perm())
+}
+
+// diff returns a unified diff of the line holding the offset.
+func diff(path string, old, new []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(old[:offset], '\n') + 1
+	line := 1 + bytes.Count(old[:lineStart], []byte{'\n'})
+	oldLine := old[lineStart:]
+	if i := bytes.IndexByte(oldLine, '\n'); i >= 0 {
+		oldLine = oldLine[:i]
+	}
+	newLine := new[lineStart:]
+	if i := bytes.IndexByte(newLine, '\n'); i >= 0 {
+		newLine = newLine[:i]
+	}
+	return fmt.Sprintf("--- a/%s\n+++ b/%s\n@@ -%d +%d @@\n-%s\n+%s\n", path, path, line, line, oldLine, newLine)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Fernando G. Vilar
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package versionfile_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	semver "github.com/vilarfg/go-semver32"
+	"github.com/vilarfg/go-semver32/versionfile"
+)
+
+func TestDetectKind(t *testing.T) {
+	var tcs = []struct {
+		path string
+		kind versionfile.Kind
+		ok   bool
+	}{
+		{"VERSION", versionfile.Plain, true},
+		{"a/version.txt", versionfile.Plain, true},
+		{"cmd/version.go", versionfile.GoConst, true},
+		{"web/package.json", versionfile.PackageJSON, true},
+		{"charts/app/Chart.yaml", versionfile.ChartYAML, true},
+		{"values.yaml", 0, false},
+	}
+
+	for i, tc := range tcs {
+		kind, ok := versionfile.DetectKind(tc.path)
+		if kind != tc.kind || ok != tc.ok {
+			t.Errorf("tc[%d] %s mismatch expected: %s %t got: %s %t", i, tc.path, tc.kind, tc.ok, kind, ok)
+		}
+	}
+}
+
+func TestLocateAndRewrite(t *testing.T) {
+	var tcs = []struct {
+		kind    versionfile.Kind
+		content string
+		exp     string
+		err     error
+	}{
+		{versionfile.Plain, "1.2.3\n", "1.3.0\n", nil},
+		{versionfile.Plain, "  v1.2\n", "  v1.3.0\n", nil},
+		{versionfile.Plain, "\n", "", versionfile.ErrNotFound},
+		{
+			versionfile.GoConst,
+			"package main\n\n// Version is the version.\nconst Version = \"v1.2.3\" // keep\n",
+			"package main\n\n// Version is the version.\nconst Version = \"v1.3.0\" // keep\n",
+			nil,
+		},
+		{
+			versionfile.GoConst,
+			"package main\n\nconst (\n\tName    = \"app\"\n\tVersion = `1.2`\n)\n",
+			"package main\n\nconst (\n\tName    = \"app\"\n\tVersion = `1.3.0`\n)\n",
+			nil,
+		},
+		{versionfile.GoConst, "package main\n\nvar Version = \"1.2.3\"\n", "", versionfile.ErrNotFound},
+		{
+			versionfile.PackageJSON,
+			"{\n  \"name\": \"app\",\n  \"deps\": {\"version\": \"9.9.9\"},\n  \"list\": [\"version\"],\n  \"version\" : \"1.2.3\"\n}\n",
+			"{\n  \"name\": \"app\",\n  \"deps\": {\"version\": \"9.9.9\"},\n  \"list\": [\"version\"],\n  \"version\" : \"1.3.0\"\n}\n",
+			nil,
+		},
+		{versionfile.PackageJSON, "{\"name\": \"version\"}", "", versionfile.ErrNotFound},
+		{
+			versionfile.ChartYAML,
+			"apiVersion: v2\nname: app # ñandú\nversion: 1.2.3 # chart\nappVersion: \"1.2.3\"\ndependencies:\n  - name: db\n    version: 9.9.9\n",
+			"apiVersion: v2\nname: app # ñandú\nversion: 1.3.0 # chart\nappVersion: \"1.2.3\"\ndependencies:\n  - name: db\n    version: 9.9.9\n",
+			nil,
+		},
+		{versionfile.ChartYAML, "name: app\nversion: \"0.1\"\n", "name: app\nversion: \"0.2.0\"\n", nil},
+		{versionfile.ChartYAML, "name: app\n", "", versionfile.ErrNotFound},
+		{0, "1.2.3", "", versionfile.ErrUnknownKind},
+	}
+
+	for i, tc := range tcs {
+		v, err := versionfile.Locate(tc.kind, []byte(tc.content))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("tc[%d] %s error mismatch expected: %v got: %v", i, tc.kind, tc.err, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		n, _ := v.Number.Bump(semver.PartMinor)
+		if got := string(versionfile.Rewrite([]byte(tc.content), v, n)); got != tc.exp {
+			t.Errorf("tc[%d] %s mismatch expected: %q got: %q", i, tc.kind, tc.exp, got)
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	dir, err := ioutil.TempDir("", "versionfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "VERSION")
+	if err := ioutil.WriteFile(path, []byte("1.2.3\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := versionfile.Bump(path, semver.PartPatch, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "--- a/" + path + "\n+++ b/" + path + "\n@@ -1 +1 @@\n-1.2.3\n+1.2.4\n"
+	if r.Diff != exp {
+		t.Errorf("diff mismatch expected: %q got: %q", exp, r.Diff)
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "1.2.3\n" {
+		t.Errorf("dry run rewrote the file: %q", b)
+	}
+
+	if r, err = versionfile.Bump(path, semver.PartMajor, false); err != nil {
+		t.Fatal(err)
+	}
+	if r.Old != semver.NewNumber(1, 2, 3) || r.New != semver.NewNumber(2, 0, 0) {
+		t.Errorf("bump mismatch expected: 1.2.3 -> 2.0.0 got: %s -> %s", r.Old.GoString(), r.New.GoString())
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "2.0.0\n" {
+		t.Errorf("content mismatch expected: %q got: %q", "2.0.0\n", b)
+	}
+
+	if _, err = versionfile.Bump(filepath.Join(dir, "values.yaml"), semver.PartMajor, false); !errors.Is(err, versionfile.ErrUnknownKind) {
+		t.Errorf("error mismatch expected: %v got: %v", versionfile.ErrUnknownKind, err)
+	}
+}